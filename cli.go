@@ -0,0 +1,323 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const statusBarWidth = 10
+
+type statusLine struct {
+	Issue     string
+	Title     string
+	State     string
+	Elapsed   string
+	Seconds   int64
+	Paused    bool
+	Limited   bool
+	Limit     string
+	Remaining string
+	Percent   int
+	Bar       string
+	Parked    int
+	Logged    string
+}
+
+func newStatusLine(status trackerStatus) statusLine {
+	a := status.Active
+	line := statusLine{
+		Issue:   a.IssueID,
+		Title:   a.Title,
+		State:   "running",
+		Elapsed: fmtDuration(a.Elapsed),
+		Seconds: int64(a.Elapsed.Seconds()),
+		Paused:  a.Paused,
+		Limited: a.Limited,
+		Parked:  len(status.Parked),
+	}
+	if logged, _ := localLogged(a.IssueID); logged > 0 {
+		line.Logged = fmtHoursMinutes(logged)
+	}
+	if a.Paused {
+		line.State = "paused"
+	}
+
+	if a.Limited && a.Limit > 0 {
+		progress := float64(a.Elapsed) / float64(a.Limit)
+		if progress > 1.0 {
+			progress = 1.0
+		}
+		filled := int(progress * statusBarWidth)
+
+		line.Limit = fmtDuration(a.Limit)
+		line.Remaining = fmtDuration(a.Limit - a.Elapsed)
+		line.Percent = int(progress * 100)
+		line.Bar = strings.Repeat("█", filled) + strings.Repeat("░", statusBarWidth-filled)
+	}
+
+	return line
+}
+
+func splitIssueArg(fs *flag.FlagSet, args []string) (string, error) {
+	issue := ""
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		issue, args = args[0], args[1:]
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return "", err
+	}
+
+	if fs.NArg() > 0 {
+		if issue != "" || fs.NArg() > 1 {
+			return "", fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+		}
+		issue = fs.Arg(0)
+	}
+
+	if issue == "" {
+		return "", nil
+	}
+
+	return parseIssueID(issue, loadPrefix())
+}
+
+func runTrackerCommand(req daemonRequest) (daemonResponse, error) {
+	if _, err := currentConfig(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Warning: config: %s\n", configErrorText(err))
+	}
+
+	if daemonAvailable() {
+		return callDaemon(req)
+	}
+
+	unlock, ok := lockTimers()
+	if !ok && req.Command != "status" {
+		return daemonResponse{}, errTimersInUse
+	}
+	if ok {
+		defer unlock()
+	}
+
+	d := &daemon{tracker: loadTracker(!ok)}
+
+	var expired []daemonSubmission
+	if ok && req.Command != "status" && !req.DryRun {
+		if records := d.tracker.checkLimit(); len(records) > 0 {
+			expired = deliverSessions(records)
+		}
+	}
+
+	if req.Command == "start" && req.Title == "" {
+		ctx, cancel := context.WithTimeout(context.Background(), titleLookupTimeout)
+		req.Title, _ = resolveIssueTitle(ctx, req.IssueID)
+		cancel()
+	}
+
+	resp := d.handle(req)
+	resp.Submissions = append(expired, resp.Submissions...)
+	if !resp.OK {
+		return resp, errors.New(resp.Error)
+	}
+
+	return resp, nil
+}
+
+func printSubmissions(w io.Writer, submissions []daemonSubmission, dryRun bool) bool {
+	ok := true
+	for _, s := range submissions {
+		switch {
+		case dryRun:
+		case s.Error != "":
+			ok = false
+			_, _ = fmt.Fprintf(w, "Posting %s for %s failed: %s (queued for retry)\n", s.Session.Rounded, s.Session.IssueID, s.Error)
+		default:
+			_, _ = fmt.Fprintf(w, "Posted %s to Linear for issue %s (comment %s)\n", s.Session.Rounded, s.Session.IssueID, s.CommentID)
+		}
+	}
+
+	return ok
+}
+
+func runTimerCommand(name string, args []string) int {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	req := daemonRequest{Command: name}
+
+	var limit *time.Duration
+	switch name {
+	case "start":
+		limit = fs.Duration("limit", 0, "stop and submit automatically after this duration (e.g. 30m)")
+	case "stop":
+		req.Command = "submit"
+		fs.BoolVar(&req.DryRun, "dry-run", false, "show what would be posted without submitting")
+	}
+
+	issue, err := splitIssueArg(fs, args)
+	if err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		return 2
+	}
+
+	switch name {
+	case "start":
+		if issue == "" {
+			detected, branch, ok := detectBranchIssue()
+			if !ok {
+				_, _ = fmt.Fprintf(os.Stderr, "Usage: unitrack start <issue> [--limit 30m]\n")
+				_, _ = fmt.Fprintf(os.Stderr, "No issue given and none found in the current git branch.\n")
+				return 2
+			}
+			_, _ = fmt.Fprintf(os.Stderr, "Detected %s from git branch %s\n", detected, branch)
+			issue = detected
+		}
+		if *limit < 0 {
+			_, _ = fmt.Fprintf(os.Stderr, "Error: --limit must be positive\n")
+			return 2
+		}
+		req.Limit = *limit
+		req.IssueID = issue
+	case "stop", "cancel":
+		req.IssueID = issue
+	default:
+		if issue != "" {
+			_, _ = fmt.Fprintf(os.Stderr, "Usage: unitrack %s\n", name)
+			return 2
+		}
+	}
+
+	resp, err := runTrackerCommand(req)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	fmt.Println(resp.Message)
+	if !printSubmissions(os.Stdout, resp.Submissions, req.DryRun) {
+		return 1
+	}
+
+	return 0
+}
+
+func printStatus(w io.Writer, status trackerStatus, withLogged bool) {
+	if status.Active == nil {
+		_, _ = fmt.Fprintln(w, "No timer running.")
+	} else {
+		a := status.Active
+		state := "running"
+		if a.Paused {
+			state = "paused"
+		}
+
+		line := fmt.Sprintf("%s  %s  %s", a.IssueID, fmtDuration(a.Elapsed), state)
+		switch {
+		case a.Limited && a.Elapsed >= a.Limit:
+			line += fmt.Sprintf("  (limit %s reached)", fmtDuration(a.Limit))
+		case a.Limited:
+			line += fmt.Sprintf("  (limit %s, %s left)", fmtDuration(a.Limit), fmtDuration(a.Limit-a.Elapsed))
+		}
+		if a.Title != "" {
+			line += "  " + a.Title
+		}
+		_, _ = fmt.Fprintln(w, line)
+
+		if withLogged {
+			printLogged(w, a.IssueID)
+		}
+	}
+
+	if len(status.Parked) > 0 {
+		_, _ = fmt.Fprintln(w, "Parked:")
+		for _, p := range status.Parked {
+			line := fmt.Sprintf("  %s  %s", p.IssueID, fmtDuration(p.Elapsed))
+			if p.Title != "" {
+				line += "  " + p.Title
+			}
+			_, _ = fmt.Fprintln(w, line)
+		}
+	}
+}
+
+func printLogged(w io.Writer, issueID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), titleLookupTimeout)
+	logged, err := issueLogged(ctx, issueID)
+	cancel()
+	if err != nil {
+		logError(fmt.Sprintf("Failed to read logged time for %s from Linear: %v", issueID, err))
+	}
+	if logged.total() > 0 || logged.Estimate != nil {
+		_, _ = fmt.Fprintf(w, "  logged so far: %s\n", logged)
+	}
+}
+
+func runStatus(args []string) int {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the timer state as JSON")
+	withLogged := fs.Bool("logged", false, "also show the time already logged on the running issue (asks Linear)")
+	format := fs.String("format", "", "print the running timer using a Go template, e.g. '{{.Issue}} {{.Elapsed}}'")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if *format != "" {
+		if *asJSON {
+			_, _ = fmt.Fprintf(os.Stderr, "Error: --json and --format cannot be combined\n")
+			return 2
+		}
+
+		return printStatusFormat(*format)
+	}
+
+	resp, err := runTrackerCommand(daemonRequest{Command: "status"})
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err = enc.Encode(resp.Status); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+
+		return 0
+	}
+
+	printSubmissions(os.Stdout, resp.Submissions, false)
+	printStatus(os.Stdout, resp.Status, *withLogged)
+
+	return 0
+}
+
+func printStatusFormat(format string) int {
+	tmpl, err := template.New("status").Parse(format)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: invalid --format: %v\n", err)
+		return 2
+	}
+
+	status := loadTracker(timersInUse()).status()
+	if status.Active == nil {
+		return 0
+	}
+
+	if err = tmpl.Execute(os.Stdout, newStatusLine(status)); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Println()
+
+	return 0
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	repoConfigName         = ".unitrack.json"
+	defaultCommentTemplate = "{{.Rounded}}"
+	configPollInterval     = 2 * time.Second
+)
+
+type configTickMsg struct{}
+
+type configReloadMsg struct {
+	cfg apiConfig
+	err error
+}
+
+var configState struct {
+	sync.Mutex
+	loaded bool
+	cfg    apiConfig
+	err    error
+	stamp  string
+}
+
+type repoConfig struct {
+	Prefix          string          `json:"prefix"`
+	Theme           string          `json:"theme"`
+	Rounding        *roundingConfig `json:"rounding"`
+	CommentTemplate string          `json:"comment_template"`
+}
+
+func configPath() string {
+	return os.Getenv("HOME") + "/.config/unitrack/unitrack.json"
+}
+
+func findRepoConfig(dir string) (string, bool) {
+	for {
+		path := filepath.Join(dir, repoConfigName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+func applyRepoConfig(cfg *apiConfig, path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+
+	var repo repoConfig
+	if err = json.Unmarshal(b, &repo); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	if repo.Prefix != "" {
+		cfg.Prefix = repo.Prefix
+	}
+	if repo.Theme != "" {
+		cfg.Theme = repo.Theme
+	}
+	if repo.Rounding != nil {
+		cfg.Rounding = repo.Rounding
+	}
+	if repo.CommentTemplate != "" {
+		cfg.CommentTemplate = repo.CommentTemplate
+	}
+
+	return nil
+}
+
+func loadConfig() (apiConfig, error) {
+	var cfg apiConfig
+
+	b, err := os.ReadFile(configPath())
+	if err != nil {
+		err = fmt.Errorf("read config: %w", err)
+	} else if err = json.Unmarshal(b, &cfg); err != nil {
+		err = fmt.Errorf("parse %s: %w", configPath(), err)
+	}
+
+	if dir, wdErr := os.Getwd(); wdErr == nil {
+		if path, ok := findRepoConfig(dir); ok {
+			if repoErr := applyRepoConfig(&cfg, path); repoErr != nil && err == nil {
+				err = repoErr
+			}
+		}
+	}
+
+	return cfg, err
+}
+
+func validateConfig(cfg apiConfig) error {
+	var errs []error
+	if cfg.APIKey == "" {
+		errs = append(errs, errors.New("api_key is missing"))
+	}
+	if cfg.Prefix != "" && !teamKeyPattern.MatchString(cfg.Prefix) {
+		errs = append(errs, fmt.Errorf("prefix must be a team key like UE, got %q", cfg.Prefix))
+	}
+	if cfg.TitleCacheHours < 0 {
+		errs = append(errs, fmt.Errorf("title_cache_hours must not be negative, got %d", cfg.TitleCacheHours))
+	}
+	if cfg.TimerExpireDays < 0 {
+		errs = append(errs, fmt.Errorf("timer_expire_days must not be negative, got %d", cfg.TimerExpireDays))
+	}
+
+	switch cfg.Theme {
+	case "", "dark", "light":
+	default:
+		errs = append(errs, fmt.Errorf("theme must be dark or light, got %q", cfg.Theme))
+	}
+
+	switch cfg.SwitchPolicy {
+	case "", switchPark, switchSubmit:
+	default:
+		errs = append(errs, fmt.Errorf("switch_policy must be park or submit, got %q", cfg.SwitchPolicy))
+	}
+
+	if _, err := newRoundingPolicy(cfg.Rounding); err != nil {
+		errs = append(errs, err)
+	}
+
+	if _, err := newKeyBindings(cfg.KeyPreset, cfg.Keys); err != nil {
+		errs = append(errs, err)
+	}
+
+	if cfg.CommentTemplate != "" {
+		if _, err := template.New("comment").Parse(cfg.CommentTemplate); err != nil {
+			errs = append(errs, fmt.Errorf("comment_template: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func configStamp() string {
+	paths := []string{configPath()}
+	if dir, err := os.Getwd(); err == nil {
+		if path, ok := findRepoConfig(dir); ok {
+			paths = append(paths, path)
+		}
+	}
+
+	var b strings.Builder
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			_, _ = fmt.Fprintf(&b, "%s:missing;", path)
+			continue
+		}
+		_, _ = fmt.Fprintf(&b, "%s:%d:%d;", path, info.ModTime().UnixNano(), info.Size())
+	}
+
+	return b.String()
+}
+
+func loadValidatedConfig() (apiConfig, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return cfg, err
+	}
+
+	return cfg, validateConfig(cfg)
+}
+
+func currentConfig() (apiConfig, error) {
+	configState.Lock()
+	defer configState.Unlock()
+
+	if !configState.loaded {
+		configState.stamp = configStamp()
+		configState.cfg, configState.err = loadValidatedConfig()
+		configState.loaded = true
+	}
+
+	return configState.cfg, configState.err
+}
+
+func reloadConfig() (apiConfig, bool, error) {
+	configState.Lock()
+	defer configState.Unlock()
+
+	stamp := configStamp()
+	if configState.loaded && stamp == configState.stamp {
+		return configState.cfg, false, configState.err
+	}
+
+	configState.stamp = stamp
+	configState.cfg, configState.err = loadValidatedConfig()
+	configState.loaded = true
+
+	return configState.cfg, true, configState.err
+}
+
+func tickConfig() tea.Cmd {
+	return tea.Tick(configPollInterval, func(time.Time) tea.Msg {
+		return configTickMsg{}
+	})
+}
+
+func reloadConfigCmd() tea.Cmd {
+	return func() tea.Msg {
+		cfg, changed, err := reloadConfig()
+		if !changed {
+			return nil
+		}
+
+		return configReloadMsg{cfg: cfg, err: err}
+	}
+}
+
+func configErrorText(err error) string {
+	return strings.ReplaceAll(err.Error(), "\n", "; ")
+}
+
+func commentBody(rec sessionRecord) string {
+	text := defaultCommentTemplate
+	if cfg, _ := currentConfig(); cfg.CommentTemplate != "" {
+		text = cfg.CommentTemplate
+	}
+
+	tmpl, err := template.New("comment").Parse(text)
+	if err != nil {
+		logError(fmt.Sprintf("Invalid comment_template, posting the rounded time only: %v", err))
+		return rec.Rounded
+	}
+
+	var b bytes.Buffer
+	if err = tmpl.Execute(&b, rec); err != nil {
+		logError(fmt.Sprintf("Failed to render comment_template, posting the rounded time only: %v", err))
+		return rec.Rounded
+	}
+
+	return b.String()
+}
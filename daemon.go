@@ -0,0 +1,436 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	daemonSocketEnv     = "UNITRACK_SOCKET"
+	daemonDialTimeout   = 2 * time.Second
+	daemonSubmitTimeout = 45 * time.Second
+)
+
+type daemonRequest struct {
+	Command   string        `json:"command"`
+	IssueID   string        `json:"issue_id,omitempty"`
+	Title     string        `json:"title,omitempty"`
+	Limit     time.Duration `json:"limit,omitempty"`
+	Delta     time.Duration `json:"delta,omitempty"`
+	DryRun    bool          `json:"dry_run,omitempty"`
+	SessionID string        `json:"session_id,omitempty"`
+}
+
+type daemonSubmission struct {
+	Session   sessionRecord `json:"session"`
+	CommentID string        `json:"comment_id,omitempty"`
+	Error     string        `json:"error,omitempty"`
+}
+
+type daemonResponse struct {
+	OK          bool               `json:"ok"`
+	Error       string             `json:"error,omitempty"`
+	Message     string             `json:"message,omitempty"`
+	Status      trackerStatus      `json:"status"`
+	Submissions []daemonSubmission `json:"submissions,omitempty"`
+}
+
+type daemonResultMsg struct {
+	request  daemonRequest
+	response daemonResponse
+	err      error
+}
+
+func daemonSocketPath() string {
+	if path := os.Getenv(daemonSocketEnv); path != "" {
+		return path
+	}
+
+	return os.Getenv("HOME") + "/.config/unitrack/unitrack.sock"
+}
+
+func daemonAvailable() bool {
+	conn, err := net.DialTimeout("unix", daemonSocketPath(), daemonDialTimeout)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+
+	return true
+}
+
+func callDaemon(req daemonRequest) (daemonResponse, error) {
+	var resp daemonResponse
+
+	conn, err := net.DialTimeout("unix", daemonSocketPath(), daemonDialTimeout)
+	if err != nil {
+		return resp, fmt.Errorf("connect to daemon: %w", err)
+	}
+	defer func(conn net.Conn) {
+		_ = conn.Close()
+	}(conn)
+
+	_ = conn.SetDeadline(time.Now().Add(daemonSubmitTimeout))
+
+	if err = json.NewEncoder(conn).Encode(req); err != nil {
+		return resp, fmt.Errorf("send to daemon: %w", err)
+	}
+
+	if err = json.NewDecoder(conn).Decode(&resp); err != nil {
+		return resp, fmt.Errorf("read from daemon: %w", err)
+	}
+
+	if !resp.OK {
+		return resp, errors.New(resp.Error)
+	}
+
+	return resp, nil
+}
+
+func daemonCmd(req daemonRequest) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := callDaemon(req)
+
+		return daemonResultMsg{request: req, response: resp, err: err}
+	}
+}
+
+func deliverSessions(records []sessionRecord) []daemonSubmission {
+	var out []daemonSubmission
+	for _, rec := range records {
+		result := submitSession(rec)().(submitResultMsg)
+
+		submission := daemonSubmission{Session: rec, CommentID: result.commentID}
+		if result.err != nil {
+			submission.Error = result.err.Error()
+		}
+		out = append(out, submission)
+	}
+
+	return out
+}
+
+func redeliverSession(id string) ([]daemonSubmission, string) {
+	result := deliverOutboxCmd(id)().(submitResultMsg)
+	if errors.Is(result.err, errOutboxEntryGone) {
+		return nil, "Submission was already delivered."
+	}
+
+	submission := daemonSubmission{
+		Session:   sessionRecord{ID: id, IssueID: result.issueID, Rounded: result.rounded},
+		CommentID: result.commentID,
+	}
+	if result.err != nil {
+		submission.Error = result.err.Error()
+	}
+
+	return []daemonSubmission{submission}, ""
+}
+
+type daemon struct {
+	tracker *tracker
+}
+
+func (d *daemon) handle(req daemonRequest) daemonResponse {
+	var (
+		result  trackerResult
+		retried []daemonSubmission
+		err     error
+	)
+
+	switch req.Command {
+	case "status":
+	case "start":
+		if req.IssueID == "" {
+			err = errors.New("issue_id is required")
+			break
+		}
+		result, err = d.tracker.start(req.IssueID, req.Title, req.Limit)
+		if err == nil && req.Title == "" {
+			go d.fetchTitle(req.IssueID)
+		}
+	case "park":
+		result, err = d.tracker.park()
+	case "pause":
+		result, err = d.tracker.pause()
+	case "resume":
+		result, err = d.tracker.resume()
+	case "adjust":
+		result, err = d.tracker.adjust(req.Delta)
+	case "submit":
+		result, err = d.tracker.submit(req.IssueID, req.DryRun)
+	case "cancel":
+		result, err = d.tracker.cancel(req.IssueID)
+	case "retry":
+		retryOutbox(req.SessionID)
+		if req.SessionID == "" {
+			result.message = retryAllText(flushOutbox())
+			break
+		}
+		retried, result.message = redeliverSession(req.SessionID)
+	default:
+		err = fmt.Errorf("unknown command %q", req.Command)
+	}
+
+	resp := daemonResponse{OK: err == nil, Message: result.message}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+
+	if req.DryRun {
+		for _, rec := range result.submitted {
+			resp.Submissions = append(resp.Submissions, daemonSubmission{Session: rec})
+		}
+	} else {
+		resp.Submissions = deliverSessions(result.submitted)
+	}
+	resp.Submissions = append(resp.Submissions, retried...)
+
+	resp.Status = d.tracker.status()
+
+	return resp
+}
+
+func (d *daemon) fetchTitle(issueID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), titleLookupTimeout)
+	defer cancel()
+
+	title, err := resolveIssueTitle(ctx, issueID)
+	if err != nil {
+		logError(fmt.Sprintf("Failed to fetch issue title for %s: %v", issueID, err))
+		return
+	}
+
+	d.tracker.setTitle(issueID, title)
+}
+
+func (d *daemon) serve(conn net.Conn) {
+	defer func(conn net.Conn) {
+		_ = conn.Close()
+	}(conn)
+
+	scanner := bufio.NewScanner(conn)
+	enc := json.NewEncoder(conn)
+	for scanner.Scan() {
+		var req daemonRequest
+		var resp daemonResponse
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			resp = daemonResponse{Error: fmt.Sprintf("invalid request: %v", err)}
+		} else {
+			resp = d.handle(req)
+		}
+
+		if err := enc.Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+func (d *daemon) loop(stop <-chan struct{}) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	lastSave := time.Now()
+	lastFlush := time.Time{}
+	lastConfigCheck := time.Now()
+	for {
+		select {
+		case <-stop:
+			d.tracker.save()
+			return
+		case now := <-ticker.C:
+			if records := d.tracker.checkLimit(); len(records) > 0 {
+				for _, rec := range records {
+					go showTimerNotification(rec.IssueID, rec.Rounded)
+				}
+				go deliverSessions(records)
+			}
+
+			if now.Sub(lastSave) >= time.Minute {
+				d.tracker.save()
+				lastSave = now
+			}
+
+			if now.Sub(lastConfigCheck) >= configPollInterval {
+				if _, changed, err := reloadConfig(); changed {
+					if err != nil {
+						logError(fmt.Sprintf("Configuration reloaded with errors: %v", configErrorText(err)))
+					} else {
+						logError("Configuration reloaded")
+					}
+				}
+				lastConfigCheck = now
+			}
+
+			if now.Sub(lastFlush) >= outboxRetryInterval {
+				go flushOutbox()
+				lastFlush = now
+			}
+		}
+	}
+}
+
+func runDaemon(args []string) int {
+	if len(args) > 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "Usage: unitrack daemon\n")
+		return 2
+	}
+
+	path := daemonSocketPath()
+	if daemonAvailable() {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: a daemon is already listening on %s\n", path)
+		return 1
+	}
+
+	unlock, ok := lockTimers()
+	if !ok {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", errTimersInUse)
+		return 1
+	}
+	defer unlock()
+
+	_ = os.MkdirAll(os.Getenv("HOME")+"/.config/unitrack", 0700)
+	_ = os.Remove(path)
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	_ = os.Chmod(path, 0600)
+
+	d := &daemon{tracker: loadTracker(false)}
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		d.loop(stop)
+		close(done)
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-signals
+		_ = listener.Close()
+	}()
+
+	logError(fmt.Sprintf("Daemon listening on %s", path))
+	fmt.Printf("unitrack daemon listening on %s\n", path)
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			break
+		}
+		go d.serve(conn)
+	}
+
+	close(stop)
+	<-done
+	_ = os.Remove(path)
+	logError("Daemon stopped")
+
+	return 0
+}
+
+func (m model) outboxCmd() tea.Cmd {
+	if m.daemon {
+		return outboxStatusCmd()
+	}
+
+	return flushOutboxCmd()
+}
+
+func (m *model) applyDaemonStatus(status trackerStatus) {
+	if status.Active == nil {
+		if m.timerActive {
+			m.resetTimer()
+		}
+	} else {
+		active := status.Active
+		if !m.timerActive || m.input.Value() != active.IssueID {
+			m.input.SetValue(active.IssueID)
+			m.input.Blur()
+			m.historyNav = false
+			m.issueTitle = ""
+		}
+		m.timerActive = true
+		m.timerPaused = active.Paused
+		m.timerValue = active.Elapsed
+		m.limitedTimer = active.Limited
+		m.timerLimit = active.Limit
+		if active.Title != "" {
+			m.issueTitle = active.Title
+		}
+	}
+
+	m.parked = nil
+	for _, p := range status.Parked {
+		m.parked = append(m.parked, savedTimer{
+			IssueID:      p.IssueID,
+			Title:        p.Title,
+			Duration:     p.Elapsed,
+			LimitedTimer: p.Limited,
+			TimerLimit:   p.Limit,
+			Paused:       true,
+		})
+	}
+}
+
+func (m model) applyDaemonResult(msg daemonResultMsg) (model, tea.Cmd) {
+	if msg.err != nil {
+		if msg.request.Command == "status" {
+			m.message = fmt.Sprintf("Lost connection to daemon: %v", msg.err)
+		} else {
+			m.message = fmt.Sprintf("Daemon: %v", msg.err)
+		}
+
+		return m, nil
+	}
+
+	m.applyDaemonStatus(msg.response.Status)
+	if msg.response.Message != "" {
+		m.message = msg.response.Message
+	}
+
+	for _, s := range msg.response.Submissions {
+		if s.Error != "" {
+			m.failedSubmission = s.Session.ID
+			m.message = fmt.Sprintf(
+				"Posting %s for %s failed: %s. Queued for retry, press '%s' to retry now.",
+				s.Session.Rounded,
+				s.Session.IssueID,
+				s.Error,
+				m.keys.Retry.Help().Key,
+			)
+		} else {
+			m.failedSubmission = ""
+			m.message = fmt.Sprintf(
+				"Posted %s to Linear for issue %s (comment %s).",
+				s.Session.Rounded,
+				s.Session.IssueID,
+				s.CommentID,
+			)
+		}
+	}
+
+	var cmds []tea.Cmd
+	if msg.request.Command == "start" {
+		cmds = append(cmds, startWorkflowCmd(msg.request.IssueID))
+	}
+	if len(msg.response.Submissions) > 0 || msg.request.Command == "retry" {
+		cmds = append(cmds, outboxStatusCmd())
+	}
+
+	return m, tea.Batch(cmds...)
+}
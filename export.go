@@ -0,0 +1,214 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+type exportedSession struct {
+	ID        string    `json:"id"`
+	IssueID   string    `json:"issue_id"`
+	Title     string    `json:"title"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Raw       string    `json:"raw"`
+	RawSecs   int64     `json:"raw_seconds"`
+	Rounded   string    `json:"rounded"`
+	Status    string    `json:"status"`
+	CommentID string    `json:"comment_id,omitempty"`
+}
+
+func toExportedSession(s sessionRecord, policy roundingPolicy) exportedSession {
+	end := s.End
+	if end.IsZero() {
+		end = s.Start.Add(s.Duration + s.Paused)
+	}
+
+	rounded := s.Rounded
+	if rounded == "" {
+		rounded = policy.format(s.Duration)
+	}
+
+	return exportedSession{
+		ID:        s.ID,
+		IssueID:   s.IssueID,
+		Title:     s.Title,
+		Start:     s.Start,
+		End:       end,
+		Raw:       fmtDuration(s.Duration),
+		RawSecs:   int64(s.Duration.Seconds()),
+		Rounded:   rounded,
+		Status:    s.Status,
+		CommentID: s.CommentID,
+	}
+}
+
+func writeCSV(w io.Writer, sessions []exportedSession) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"issue_id", "title", "start", "end", "raw", "raw_seconds", "rounded", "status"}); err != nil {
+		return err
+	}
+
+	for _, s := range sessions {
+		err := cw.Write([]string{
+			s.IssueID,
+			s.Title,
+			s.Start.Format(time.RFC3339),
+			s.End.Format(time.RFC3339),
+			s.Raw,
+			fmt.Sprintf("%d", s.RawSecs),
+			s.Rounded,
+			s.Status,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func writeJSON(w io.Writer, sessions []exportedSession) error {
+	if sessions == nil {
+		sessions = []exportedSession{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(sessions)
+}
+
+func icsEscape(text string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(text)
+}
+
+func icsFold(line string) string {
+	if len(line) <= 75 {
+		return line + "\r\n"
+	}
+
+	var b strings.Builder
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && (line[cut]&0xC0) == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+
+	return b.String()
+}
+
+func writeICS(w io.Writer, sessions []exportedSession) error {
+	const stamp = "20060102T150405Z"
+
+	var b strings.Builder
+	b.WriteString(icsFold("BEGIN:VCALENDAR"))
+	b.WriteString(icsFold("VERSION:2.0"))
+	b.WriteString(icsFold("PRODID:-//unitrack//" + version + "//EN"))
+	b.WriteString(icsFold("CALSCALE:GREGORIAN"))
+
+	now := time.Now().UTC().Format(stamp)
+	for _, s := range sessions {
+		summary := s.IssueID
+		if s.Title != "" {
+			summary += ": " + s.Title
+		}
+		summary += " (" + s.Rounded + ")"
+
+		description := fmt.Sprintf("Raw: %s\nRounded: %s\nStatus: %s", s.Raw, s.Rounded, s.Status)
+
+		b.WriteString(icsFold("BEGIN:VEVENT"))
+		b.WriteString(icsFold("UID:" + icsEscape(s.ID) + "@unitrack"))
+		b.WriteString(icsFold("DTSTAMP:" + now))
+		b.WriteString(icsFold("DTSTART:" + s.Start.UTC().Format(stamp)))
+		b.WriteString(icsFold("DTEND:" + s.End.UTC().Format(stamp)))
+		b.WriteString(icsFold("SUMMARY:" + icsEscape(summary)))
+		b.WriteString(icsFold("DESCRIPTION:" + icsEscape(description)))
+		b.WriteString(icsFold("CATEGORIES:" + icsEscape(s.IssueID)))
+		b.WriteString(icsFold("END:VEVENT"))
+	}
+
+	b.WriteString(icsFold("END:VCALENDAR"))
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+func runExport(args []string) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", "csv", "output format: csv, json or ics")
+	from := fs.String("from", "", "first day to include (YYYY-MM-DD)")
+	to := fs.String("to", "", "last day to include (YYYY-MM-DD)")
+	issue := fs.String("issue", "", "only include sessions for this issue ID")
+	output := fs.String("output", "", "write to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	var write func(io.Writer, []exportedSession) error
+	switch *format {
+	case "csv":
+		write = writeCSV
+	case "json":
+		write = writeJSON
+	case "ics", "ical":
+		write = writeICS
+	default:
+		_, _ = fmt.Fprintf(os.Stderr, "Error: --format must be csv, json or ics, got %q\n", *format)
+		return 2
+	}
+
+	start, end, err := parseDateRange(*from, *to, time.Time{})
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	policy := loadRoundingPolicy()
+
+	var sessions []exportedSession
+	for _, s := range filterSessions(loadSessions(), start, end, *issue) {
+		sessions = append(sessions, toExportedSession(s, policy))
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		defer func(f *os.File) {
+			err := f.Close()
+			if err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "Could not close %s: %v\n", *output, err)
+			}
+		}(f)
+		w = f
+	}
+
+	if err = write(w, sessions); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
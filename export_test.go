@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestICSEscape(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{in: "UE-1 Fix login", want: "UE-1 Fix login"},
+		{in: "a,b;c", want: `a\,b\;c`},
+		{in: `C:\temp`, want: `C:\\temp`},
+		{in: "line 1\nline 2", want: `line 1\nline 2`},
+		{in: "line 1\r\nline 2", want: `line 1\nline 2`},
+	}
+
+	for _, tt := range tests {
+		if got := icsEscape(tt.in); got != tt.want {
+			t.Errorf("icsEscape(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestICSFold(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{name: "short", in: "SUMMARY:UE-1"},
+		{name: "exactly 75", in: strings.Repeat("a", 75)},
+		{name: "long", in: "DESCRIPTION:" + strings.Repeat("abcdefghij", 20)},
+		{name: "multibyte", in: "SUMMARY:" + strings.Repeat("Grüße ", 40)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := icsFold(tt.in)
+			if !strings.HasSuffix(got, "\r\n") {
+				t.Fatalf("icsFold() = %q, want a trailing CRLF", got)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(got, "\r\n"), "\r\n")
+			for i, line := range lines {
+				if len(line) > 75 {
+					t.Errorf("line %d has %d octets, want at most 75", i, len(line))
+				}
+				if i > 0 && !strings.HasPrefix(line, " ") {
+					t.Errorf("continuation line %d = %q, want a leading space", i, line)
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d = %q splits a UTF-8 sequence", i, line)
+				}
+			}
+
+			if unfolded := strings.ReplaceAll(strings.TrimSuffix(got, "\r\n"), "\r\n ", ""); unfolded != tt.in {
+				t.Errorf("unfolded = %q, want %q", unfolded, tt.in)
+			}
+		})
+	}
+}
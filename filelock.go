@@ -0,0 +1,34 @@
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+func lockFile(path string, wait bool) (func(), bool, error) {
+	_ = os.MkdirAll(os.Getenv("HOME")+"/.config/unitrack", 0700)
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, false, err
+	}
+
+	how := syscall.LOCK_EX
+	if !wait {
+		how |= syscall.LOCK_NB
+	}
+
+	if err = syscall.Flock(int(f.Fd()), how); err != nil {
+		_ = f.Close()
+		if err == syscall.EWOULDBLOCK {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return func() {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		_ = f.Close()
+	}, true, nil
+}
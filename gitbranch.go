@@ -0,0 +1,108 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type startIssueMsg struct {
+	issueID string
+}
+
+func findGitDir(dir string) (string, bool) {
+	for {
+		path := filepath.Join(dir, ".git")
+		info, err := os.Stat(path)
+		if err == nil {
+			if info.IsDir() {
+				return path, true
+			}
+
+			b, err := os.ReadFile(path)
+			if err != nil {
+				return "", false
+			}
+			gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(b)), "gitdir:")
+			if !ok {
+				return "", false
+			}
+			gitDir = strings.TrimSpace(gitDir)
+			if !filepath.IsAbs(gitDir) {
+				gitDir = filepath.Join(dir, gitDir)
+			}
+
+			return gitDir, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+func currentBranch(dir string) (string, bool) {
+	gitDir, ok := findGitDir(dir)
+	if !ok {
+		return "", false
+	}
+
+	b, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return "", false
+	}
+
+	branch, ok := strings.CutPrefix(strings.TrimSpace(string(b)), "ref: refs/heads/")
+	if !ok || branch == "" {
+		return "", false
+	}
+
+	return branch, true
+}
+
+func issueFromBranch(branch, prefix string) (string, bool) {
+	if prefix == "" {
+		return "", false
+	}
+
+	tokens := strings.FieldsFunc(branch, func(r rune) bool {
+		return r == '/' || r == '-' || r == '_' || r == '.'
+	})
+
+	for i := 0; i+1 < len(tokens); i++ {
+		if !strings.EqualFold(tokens[i], prefix) {
+			continue
+		}
+		if id, err := parseIssueID(tokens[i]+"-"+tokens[i+1], prefix); err == nil {
+			return id, true
+		}
+	}
+
+	return "", false
+}
+
+func detectBranchIssue() (string, string, bool) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", "", false
+	}
+
+	branch, ok := currentBranch(dir)
+	if !ok {
+		return "", "", false
+	}
+
+	issueID, ok := issueFromBranch(branch, loadPrefix())
+
+	return issueID, branch, ok
+}
+
+func startIssueCmd(issueID string) tea.Cmd {
+	return func() tea.Msg {
+		return startIssueMsg{issueID: issueID}
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import "testing"
+
+func TestIssueFromBranch(t *testing.T) {
+	tests := []struct {
+		branch string
+		prefix string
+		want   string
+	}{
+		{branch: "alice/ue-1234-fix-login", prefix: "UE", want: "UE-1234"},
+		{branch: "feature/UE-7", prefix: "UE", want: "UE-7"},
+		{branch: "ue_99_cleanup", prefix: "UE", want: "UE-99"},
+		{branch: "fix-12/ue-34-login", prefix: "UE", want: "UE-34"},
+		{branch: "eng-42-search", prefix: "eng", want: "ENG-42"},
+		{branch: "release-1.2", prefix: "UE"},
+		{branch: "renovate/node-18.x", prefix: "UE"},
+		{branch: "net-0.17.0", prefix: "UE"},
+		{branch: "fix/issue-42", prefix: "UE"},
+		{branch: "ue-next", prefix: "UE"},
+		{branch: "main", prefix: "UE"},
+		{branch: "ue-1", prefix: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			got, ok := issueFromBranch(tt.branch, tt.prefix)
+			if ok != (tt.want != "") || got != tt.want {
+				t.Errorf("issueFromBranch(%q, %q) = %q, %v, want %q", tt.branch, tt.prefix, got, ok, tt.want)
+			}
+		})
+	}
+}
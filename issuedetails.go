@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type issueDetailsMsg struct {
+	issueID string
+	issue   linearIssue
+	err     error
+}
+
+func fetchIssueDetailsCmd(issueID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), titleLookupTimeout)
+		defer cancel()
+
+		client, err := newLinearAPI()
+		if err != nil {
+			return issueDetailsMsg{issueID: issueID, err: err}
+		}
+
+		issue, err := client.issueDetails(ctx, issueID)
+		if err != nil {
+			logError(fmt.Sprintf("Failed to fetch issue details for %s: %v", issueID, err))
+		}
+
+		return issueDetailsMsg{issueID: issueID, issue: issue, err: err}
+	}
+}
+
+func (m model) followActiveIssue(cmd tea.Cmd) (model, tea.Cmd) {
+	if !m.timerActive {
+		m.detailsIssue = ""
+		m.details = nil
+
+		return m, cmd
+	}
+
+	issueID := m.input.Value()
+	if issueID == "" || issueID == m.detailsIssue {
+		return m, cmd
+	}
+
+	m.detailsIssue = issueID
+	m.details = nil
+
+	return m, tea.Batch(cmd, fetchIssueDetailsCmd(issueID), fetchLoggedCmd(issueID))
+}
+
+func (m model) applyIssueDetails(msg issueDetailsMsg) model {
+	if msg.err != nil || msg.issueID != m.detailsIssue {
+		return m
+	}
+
+	m.details = &msg.issue
+	if m.issueTitle == "" {
+		m.issueTitle = truncateTitle(msg.issue.Title, titleWidth)
+	}
+
+	return m
+}
+
+func issueDetailsLine(issue linearIssue) string {
+	var parts []string
+	if issue.State.Name != "" {
+		parts = append(parts, issue.State.Name)
+	}
+
+	if issue.Assignee != nil {
+		parts = append(parts, issue.Assignee.Name)
+	} else {
+		parts = append(parts, "Unassigned")
+	}
+
+	if issue.Priority > 0 && issue.PriorityLabel != "" {
+		parts = append(parts, issue.PriorityLabel)
+	}
+
+	if estimate := formatEstimate(issue.Estimate); estimate != "" {
+		parts = append(parts, estimate)
+	}
+
+	if len(issue.Labels.Nodes) > 0 {
+		names := make([]string, 0, len(issue.Labels.Nodes))
+		for _, label := range issue.Labels.Nodes {
+			names = append(names, label.Name)
+		}
+		parts = append(parts, strings.Join(names, ", "))
+	}
+
+	if issue.Cycle != nil {
+		cycle := fmt.Sprintf("Cycle %d", issue.Cycle.Number)
+		if issue.Cycle.Name != "" {
+			cycle += ": " + issue.Cycle.Name
+		}
+		parts = append(parts, cycle)
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+func (m model) issueDetailsView() string {
+	if m.details == nil {
+		return ""
+	}
+
+	return detailStyle.Render(issueDetailsLine(*m.details))
+}
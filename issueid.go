@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	defaultPrefix = "UE"
+
+	issueInputLimit = 256
+)
+
+var (
+	errEmptyIssueID = errors.New("issue ID cannot be empty")
+
+	issueIDPattern    = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9]*)-([0-9]+)$`)
+	issueURLPattern   = regexp.MustCompile(`^https?://linear\.app/[^/]+/issue/([A-Za-z][A-Za-z0-9]*-[0-9]+)(?:[/?#].*)?$`)
+	bareNumberPattern = regexp.MustCompile(`^#?([0-9]+)$`)
+	teamKeyPattern    = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+)
+
+func issueIDErrorMessage(err error) string {
+	if errors.Is(err, errEmptyIssueID) {
+		return "Issue ID cannot be empty."
+	}
+
+	return fmt.Sprintf("Invalid issue: %v.", err)
+}
+
+func loadPrefix() string {
+	cfg, _ := currentConfig()
+	if cfg.Prefix == "" {
+		return defaultPrefix
+	}
+
+	return cfg.Prefix
+}
+
+func parseIssueID(value, prefix string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", errEmptyIssueID
+	}
+
+	if match := issueURLPattern.FindStringSubmatch(value); match != nil {
+		value = match[1]
+	}
+
+	if match := bareNumberPattern.FindStringSubmatch(value); match != nil {
+		value = prefix + "-" + match[1]
+	}
+
+	if !issueIDPattern.MatchString(value) {
+		return "", fmt.Errorf("%q is not a Linear issue ID or URL", value)
+	}
+
+	return strings.ToUpper(value), nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseIssueID(t *testing.T) {
+	tests := []struct {
+		value   string
+		prefix  string
+		want    string
+		wantErr bool
+	}{
+		{value: "UE-1234", prefix: "UE", want: "UE-1234"},
+		{value: "ue-1234", prefix: "UE", want: "UE-1234"},
+		{value: "1234", prefix: "UE", want: "UE-1234"},
+		{value: "#42", prefix: "ENG", want: "ENG-42"},
+		{value: "  ENG-42 ", prefix: "UE", want: "ENG-42"},
+		{value: "https://linear.app/acme/issue/ENG-42/some-title", prefix: "UE", want: "ENG-42"},
+		{value: "https://linear.app/acme/issue/eng-42", prefix: "UE", want: "ENG-42"},
+		{value: "UE-", prefix: "UE", wantErr: true},
+		{value: "login bug", prefix: "UE", wantErr: true},
+		{value: "https://example.com/issue/ENG-42", prefix: "UE", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseIssueID(tt.value, tt.prefix)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseIssueID(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseIssueID(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIssueIDEmpty(t *testing.T) {
+	if _, err := parseIssueID("   ", "UE"); !errors.Is(err, errEmptyIssueID) {
+		t.Errorf("parseIssueID() error = %v, want errEmptyIssueID", err)
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+const keyPresetDefault = "default"
+
+var keyPresets = map[string]map[string][]string{
+	"vim": {
+		"up":                {"up", "ctrl+k"},
+		"down":              {"down", "ctrl+j"},
+		"add_time":          {"+", "ctrl+a"},
+		"sub_time":          {"-", "ctrl+x"},
+		"timers.switch":     {"enter", "r", "l"},
+		"timers.discard":    {"d", "x"},
+		"timers.back":       {"esc", "h"},
+		"search.up":         {"up", "ctrl+k"},
+		"search.down":       {"down", "ctrl+j"},
+		"my_issues.select":  {"enter", "l"},
+		"my_issues.refresh": {"ctrl+r", "R"},
+		"my_issues.back":    {"esc", "h"},
+	},
+	"emacs": {
+		"up":             {"up", "ctrl+p"},
+		"down":           {"down", "ctrl+n"},
+		"back":           {"esc", "ctrl+g", "ctrl+c"},
+		"timers.up":      {"up", "ctrl+p"},
+		"timers.down":    {"down", "ctrl+n"},
+		"timers.back":    {"esc", "ctrl+g"},
+		"search.back":    {"esc", "ctrl+g", "ctrl+c"},
+		"my_issues.up":   {"up", "ctrl+p"},
+		"my_issues.down": {"down", "ctrl+n"},
+		"my_issues.back": {"esc", "ctrl+g"},
+	},
+}
+
+type keyBindings struct {
+	main     keyMap
+	list     timerListKeyMap
+	search   searchKeyMap
+	myIssues myIssuesKeyMap
+}
+
+func defaultKeyBindings() keyBindings {
+	b, _ := newKeyBindings(keyPresetDefault, nil)
+
+	return b
+}
+
+func (b *keyBindings) actions() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"quit":          &b.main.Quit,
+		"start":         &b.main.Start,
+		"submit":        &b.main.Submit,
+		"pause":         &b.main.Pause,
+		"resume":        &b.main.Resume,
+		"cancel":        &b.main.Cancel,
+		"up":            &b.main.Up,
+		"down":          &b.main.Down,
+		"help":          &b.main.Help,
+		"limited_timer": &b.main.LimitedTimer,
+		"add_time":      &b.main.AddTime,
+		"sub_time":      &b.main.SubTime,
+		"retry":         &b.main.Retry,
+		"timers":        &b.main.Timers,
+		"switch":        &b.main.Switch,
+		"search":        &b.main.Search,
+		"my_issues":     &b.main.MyIssues,
+		"confirm":       &b.main.Confirm,
+		"deny":          &b.main.Deny,
+		"back":          &b.main.Back,
+
+		"timers.up":      &b.list.Up,
+		"timers.down":    &b.list.Down,
+		"timers.switch":  &b.list.Switch,
+		"timers.submit":  &b.list.Submit,
+		"timers.discard": &b.list.Discard,
+		"timers.new":     &b.list.New,
+		"timers.back":    &b.list.Back,
+
+		"search.up":     &b.search.Up,
+		"search.down":   &b.search.Down,
+		"search.select": &b.search.Select,
+		"search.back":   &b.search.Back,
+
+		"my_issues.up":      &b.myIssues.Up,
+		"my_issues.down":    &b.myIssues.Down,
+		"my_issues.select":  &b.myIssues.Select,
+		"my_issues.group":   &b.myIssues.Group,
+		"my_issues.refresh": &b.myIssues.Refresh,
+		"my_issues.back":    &b.myIssues.Back,
+	}
+}
+
+func keyHelpLabel(keys []string) string {
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		switch k {
+		case "up":
+			labels[i] = "↑"
+		case "down":
+			labels[i] = "↓"
+		default:
+			labels[i] = k
+		}
+	}
+
+	return strings.Join(labels, "/")
+}
+
+func remapKey(b *key.Binding, keys []string) {
+	b.SetKeys(keys...)
+	b.SetHelp(keyHelpLabel(keys), b.Help().Desc)
+}
+
+func addKeys(b *key.Binding, from ...key.Binding) {
+	keys := b.Keys()
+	for _, f := range from {
+		for _, k := range f.Keys() {
+			if !slices.Contains(keys, k) {
+				keys = append(keys, k)
+			}
+		}
+	}
+	b.SetKeys(keys...)
+}
+
+func findKeyConflicts(screen string, bindings [][]key.Binding) []error {
+	var errs []error
+	seen := make(map[string]string)
+	for _, row := range bindings {
+		for _, b := range row {
+			for _, k := range b.Keys() {
+				if other, ok := seen[k]; ok {
+					errs = append(errs, fmt.Errorf("keys: %q is bound to both %q and %q on the %s screen", k, other, b.Help().Desc, screen))
+					continue
+				}
+				seen[k] = b.Help().Desc
+			}
+		}
+	}
+
+	return errs
+}
+
+func newKeyBindings(preset string, overrides map[string][]string) (keyBindings, error) {
+	b := keyBindings{
+		main:     defaultKeyMap(),
+		list:     defaultTimerListKeyMap(),
+		search:   defaultSearchKeyMap(),
+		myIssues: defaultMyIssuesKeyMap(),
+	}
+	actions := b.actions()
+
+	var errs []error
+	if preset != "" && preset != keyPresetDefault {
+		if remaps, ok := keyPresets[preset]; ok {
+			for name, keys := range remaps {
+				remapKey(actions[name], keys)
+			}
+		} else {
+			errs = append(errs, fmt.Errorf("key_preset must be default, vim or emacs, got %q", preset))
+		}
+	}
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		binding, ok := actions[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("keys: unknown action %q", name))
+			continue
+		}
+		if len(overrides[name]) == 0 {
+			errs = append(errs, fmt.Errorf("keys: action %q has no keys", name))
+			continue
+		}
+		remapKey(binding, overrides[name])
+	}
+
+	addKeys(&b.list.Back, b.main.Timers, b.main.Quit)
+	addKeys(&b.myIssues.Back, b.main.MyIssues, b.main.Quit)
+
+	errs = append(errs, findKeyConflicts("main", b.main.FullHelp())...)
+	errs = append(errs, findKeyConflicts("confirmation", [][]key.Binding{{b.main.Confirm, b.main.Deny}})...)
+	errs = append(errs, findKeyConflicts("prompt", [][]key.Binding{{b.main.Start, b.main.Back}})...)
+	errs = append(errs, findKeyConflicts("timers", b.list.FullHelp())...)
+	errs = append(errs, findKeyConflicts("search", b.search.FullHelp())...)
+	errs = append(errs, findKeyConflicts("my issues", b.myIssues.FullHelp())...)
+
+	return b, errors.Join(errs...)
+}
+
+func applyKeyBindings(b keyBindings) {
+	keys = b.main
+	listKeys = b.list
+	searchKeys = b.search
+	myIssuesKeys = b.myIssues
+}
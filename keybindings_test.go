@@ -0,0 +1,104 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNewKeyBindings(t *testing.T) {
+	tests := []struct {
+		name      string
+		preset    string
+		overrides map[string][]string
+		wantErr   bool
+	}{
+		{name: "defaults"},
+		{name: "default preset", preset: keyPresetDefault},
+		{name: "vim", preset: "vim"},
+		{name: "emacs", preset: "emacs"},
+		{name: "override", overrides: map[string][]string{"submit": {"S"}, "timers.discard": {"x", "delete"}}},
+		{name: "vim with override", preset: "vim", overrides: map[string][]string{"quit": {"ctrl+q"}}},
+		{name: "unknown preset", preset: "nano", wantErr: true},
+		{name: "unknown action", overrides: map[string][]string{"launch": {"L"}}, wantErr: true},
+		{name: "no keys", overrides: map[string][]string{"submit": {}}, wantErr: true},
+		{name: "main conflict", overrides: map[string][]string{"submit": {"p"}}, wantErr: true},
+		{name: "timers conflict", overrides: map[string][]string{"timers.submit": {"d"}}, wantErr: true},
+		{name: "search conflict", overrides: map[string][]string{"search.up": {"enter"}}, wantErr: true},
+		{name: "my issues conflict", overrides: map[string][]string{"my_issues.group": {"j"}}, wantErr: true},
+		{name: "confirmation conflict", overrides: map[string][]string{"deny": {"y"}}, wantErr: true},
+		{name: "prompt conflict", overrides: map[string][]string{"back": {"enter"}}, wantErr: true},
+		{name: "same key on other screens", overrides: map[string][]string{"timers.new": {"s", "n"}, "timers.submit": {"S"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newKeyBindings(tt.preset, tt.overrides)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newKeyBindings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewKeyBindingsRemaps(t *testing.T) {
+	b, err := newKeyBindings("vim", map[string][]string{"submit": {"S"}})
+	if err != nil {
+		t.Fatalf("newKeyBindings() error = %v", err)
+	}
+
+	if got := b.main.Submit.Keys(); !slices.Equal(got, []string{"S"}) {
+		t.Errorf("submit keys = %q, want [S]", got)
+	}
+	if got := b.main.Submit.Help().Key; got != "S" {
+		t.Errorf("submit help key = %q, want S", got)
+	}
+	if got := b.main.Up.Help().Key; got != "↑/ctrl+k" {
+		t.Errorf("up help key = %q, want ↑/ctrl+k", got)
+	}
+	if got := b.list.Switch.Keys(); !slices.Contains(got, "l") {
+		t.Errorf("timers.switch keys = %q, want l for vim", got)
+	}
+	if got := defaultKeyBindings().main.Submit.Keys(); !slices.Equal(got, []string{"s"}) {
+		t.Errorf("default submit keys = %q, want [s] after remapping a copy", got)
+	}
+}
+
+func TestNewKeyBindingsBackFollowsToggles(t *testing.T) {
+	tests := []struct {
+		name      string
+		preset    string
+		overrides map[string][]string
+		list      []string
+		myIssues  []string
+	}{
+		{name: "defaults", list: []string{"esc", "t", "q", "ctrl+c"}, myIssues: []string{"esc", "m", "q", "ctrl+c"}},
+		{name: "vim", preset: "vim", list: []string{"esc", "h", "t", "q", "ctrl+c"}, myIssues: []string{"esc", "h", "m", "q", "ctrl+c"}},
+		{
+			name:      "remapped toggles",
+			overrides: map[string][]string{"timers": {"T"}, "my_issues": {"M"}, "quit": {"ctrl+q"}},
+			list:      []string{"esc", "T", "ctrl+q"},
+			myIssues:  []string{"esc", "M", "ctrl+q"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := newKeyBindings(tt.preset, tt.overrides)
+			if err != nil {
+				t.Fatalf("newKeyBindings() error = %v", err)
+			}
+			if got := b.list.Back.Keys(); !slices.Equal(got, tt.list) {
+				t.Errorf("timers.back keys = %q, want %q", got, tt.list)
+			}
+			if got := b.myIssues.Back.Keys(); !slices.Equal(got, tt.myIssues) {
+				t.Errorf("my_issues.back keys = %q, want %q", got, tt.myIssues)
+			}
+		})
+	}
+}
+
+func TestNewKeyBindingsTimersConflict(t *testing.T) {
+	if _, err := newKeyBindings("", map[string][]string{"timers": {"d"}}); err == nil {
+		t.Error("newKeyBindings() error = nil, want a conflict with timers.discard")
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	sessionQueued    = "queued"
+	sessionSubmitted = "submitted"
+	sessionFailed    = "failed"
+)
+
+type sessionRecord struct {
+	ID         string        `json:"id"`
+	IssueID    string        `json:"issue_id"`
+	Title      string        `json:"title,omitempty"`
+	Start      time.Time     `json:"start"`
+	End        time.Time     `json:"end"`
+	Duration   time.Duration `json:"duration"`
+	Paused     time.Duration `json:"paused"`
+	Adjustment time.Duration `json:"adjustment"`
+	Rounded    string        `json:"rounded"`
+	Limited    bool          `json:"limited,omitempty"`
+	AutoSubmit bool          `json:"auto_submit,omitempty"`
+	Status     string        `json:"status"`
+	Error      string        `json:"error,omitempty"`
+	CommentID  string        `json:"comment_id,omitempty"`
+	RecordedAt time.Time     `json:"recorded_at"`
+}
+
+var ledgerMu sync.Mutex
+
+func ledgerPath() string {
+	return os.Getenv("HOME") + "/.config/unitrack/sessions.jsonl"
+}
+
+func newSessionID(issueID string, start time.Time) string {
+	return fmt.Sprintf("%s-%d", issueID, start.UnixNano())
+}
+
+func appendSession(rec sessionRecord) {
+	rec.RecordedAt = time.Now()
+
+	b, err := json.Marshal(rec)
+	if err != nil {
+		logError(fmt.Sprintf("Failed to marshal session record: %v", err))
+		return
+	}
+
+	ledgerMu.Lock()
+	defer ledgerMu.Unlock()
+
+	_ = os.MkdirAll(os.Getenv("HOME")+"/.config/unitrack", 0700)
+
+	f, err := os.OpenFile(ledgerPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		logError(fmt.Sprintf("Failed to open session ledger: %v", err))
+		return
+	}
+	defer func(f *os.File) {
+		err := f.Close()
+		if err != nil {
+			logError(fmt.Sprintf("Failed to close session ledger: %v", err))
+		}
+	}(f)
+
+	if _, err = f.Write(append(b, '\n')); err != nil {
+		logError(fmt.Sprintf("Failed to write session record: %v", err))
+	}
+}
+
+func loadSessions() []sessionRecord {
+	ledgerMu.Lock()
+	defer ledgerMu.Unlock()
+
+	f, err := os.Open(ledgerPath())
+	if err != nil {
+		return nil
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
+
+	index := make(map[string]int)
+	var out []sessionRecord
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var rec sessionRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			logError(fmt.Sprintf("Skipping malformed session record: %v", err))
+			continue
+		}
+
+		if i, ok := index[rec.ID]; ok && rec.ID != "" {
+			out[i] = rec
+			continue
+		}
+
+		index[rec.ID] = len(out)
+		out = append(out, rec)
+	}
+
+	return out
+}
+
+func newSessionRecord(t savedTimer, rounded string, autoSubmit bool) sessionRecord {
+	end := time.Now()
+
+	paused := t.TotalPaused
+	if t.Paused && !t.PausedAt.IsZero() {
+		paused += end.Sub(t.PausedAt)
+	}
+
+	start := t.SessionStart
+	if start.IsZero() {
+		start = t.StartTime
+	}
+
+	return sessionRecord{
+		ID:         newSessionID(t.IssueID, start),
+		IssueID:    t.IssueID,
+		Title:      t.Title,
+		Start:      start,
+		End:        end,
+		Duration:   t.Duration,
+		Paused:     paused,
+		Adjustment: t.Adjustment,
+		Rounded:    rounded,
+		Limited:    t.LimitedTimer,
+		AutoSubmit: autoSubmit,
+	}
+}
@@ -0,0 +1,501 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	defaultLinearEndpoint = "https://api.linear.app/graphql"
+	linearEndpointEnv     = "UNITRACK_LINEAR_ENDPOINT"
+)
+
+var errLinearAuth = errors.New("linear rejected the API key or its scopes")
+
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+type graphQLError struct {
+	Message    string `json:"message"`
+	Extensions struct {
+		Code                   string `json:"code"`
+		Type                   string `json:"type"`
+		UserPresentableMessage string `json:"userPresentableMessage"`
+	} `json:"extensions"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphQLError  `json:"errors"`
+}
+
+type linearAPIError struct {
+	Status   int
+	Messages []string
+	Auth     bool
+}
+
+func (e *linearAPIError) Error() string {
+	text := strings.Join(e.Messages, "; ")
+	if text == "" {
+		text = fmt.Sprintf("status %d", e.Status)
+	}
+
+	return "linear API: " + text
+}
+
+func (e *linearAPIError) Is(target error) bool {
+	return target == errLinearAuth && e.Auth
+}
+
+type linearAPI interface {
+	createComment(issueID, body string) (string, error)
+	issueTitle(ctx context.Context, issueID string) (string, error)
+	issueDetails(ctx context.Context, issueID string) (linearIssue, error)
+	issueWorkLog(ctx context.Context, issueID string) (workLog, error)
+	issueStartInfo(ctx context.Context, issueID string) (startInfo, error)
+	updateIssue(ctx context.Context, issueID string, input map[string]any) (string, error)
+	searchIssues(ctx context.Context, term string, first int) ([]linearIssue, error)
+	assignedIssues(ctx context.Context, first int) ([]linearIssue, error)
+}
+
+var newLinearAPI = func() (linearAPI, error) {
+	return loadLinearClient()
+}
+
+type linearIssue struct {
+	Identifier    string   `json:"identifier"`
+	Title         string   `json:"title"`
+	Estimate      *float64 `json:"estimate"`
+	Priority      int      `json:"priority"`
+	PriorityLabel string   `json:"priorityLabel"`
+	State         struct {
+		Name     string  `json:"name"`
+		Type     string  `json:"type"`
+		Position float64 `json:"position"`
+	} `json:"state"`
+	Assignee *struct {
+		Name string `json:"name"`
+	} `json:"assignee"`
+	Labels struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	Cycle *struct {
+		Number int    `json:"number"`
+		Name   string `json:"name"`
+	} `json:"cycle"`
+}
+
+type issueComment struct {
+	ID   string
+	Body string
+	Mine bool
+}
+
+type workLog struct {
+	Estimate *float64
+	Comments []issueComment
+}
+
+type workflowState struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Type     string  `json:"type"`
+	Position float64 `json:"position"`
+}
+
+type startInfo struct {
+	ViewerID      string
+	State         workflowState
+	AssigneeID    string
+	StartedStates []workflowState
+}
+
+type linearClient struct {
+	endpoint string
+	apiKey   string
+	http     *resty.Client
+}
+
+func newLinearClient(endpoint, apiKey string) *linearClient {
+	return &linearClient{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		http:     resty.New().SetTimeout(30 * time.Second),
+	}
+}
+
+func linearEndpoint(cfg apiConfig) string {
+	if endpoint := os.Getenv(linearEndpointEnv); endpoint != "" {
+		return endpoint
+	}
+
+	if cfg.LinearEndpoint != "" {
+		return cfg.LinearEndpoint
+	}
+
+	return defaultLinearEndpoint
+}
+
+func loadLinearClient() (*linearClient, error) {
+	cfg, err := currentConfig()
+	if errors.Is(err, fs.ErrNotExist) {
+		logError(fmt.Sprintf("Failed to read config: %v", err))
+		return nil, err
+	}
+	if cfg.APIKey == "" {
+		logError(fmt.Sprintf("Failed to parse config or missing key: %v", err))
+		return nil, fmt.Errorf("%w: api_key missing or config invalid", errLinearAuth)
+	}
+
+	return newLinearClient(linearEndpoint(cfg), cfg.APIKey), nil
+}
+
+func (c *linearClient) do(ctx context.Context, operation, query string, variables map[string]any, out any) error {
+	resp, err := c.http.R().
+		SetContext(ctx).
+		SetHeader("Authorization", c.apiKey).
+		SetHeader("Content-Type", "application/json").
+		SetBody(graphQLRequest{Query: query, OperationName: operation, Variables: variables}).
+		Post(c.endpoint)
+	if err != nil {
+		logError(fmt.Sprintf("Linear API error: %v", err))
+		return fmt.Errorf("linear API: %w", err)
+	}
+
+	logError(fmt.Sprintf("Linear API %s status: %d, response: %s", operation, resp.StatusCode(), resp.String()))
+
+	var result graphQLResponse
+	if err = json.Unmarshal(resp.Body(), &result); err != nil && resp.StatusCode() == 200 {
+		logError(fmt.Sprintf("Failed to parse Linear API response: %v", err))
+		return fmt.Errorf("parse linear API response: %w", err)
+	}
+
+	if len(result.Errors) > 0 || resp.StatusCode() != 200 {
+		apiErr := &linearAPIError{
+			Status: resp.StatusCode(),
+			Auth:   resp.StatusCode() == 401 || resp.StatusCode() == 403,
+		}
+		for _, e := range result.Errors {
+			text := e.Message
+			if e.Extensions.UserPresentableMessage != "" {
+				text = e.Extensions.UserPresentableMessage
+			}
+			apiErr.Messages = append(apiErr.Messages, text)
+
+			code := e.Extensions.Code
+			if code == "AUTHENTICATION_ERROR" || code == "FORBIDDEN" ||
+				strings.Contains(strings.ToLower(text), "scope") {
+				apiErr.Auth = true
+			}
+		}
+
+		return apiErr
+	}
+
+	if out == nil || len(result.Data) == 0 {
+		return nil
+	}
+
+	if err = json.Unmarshal(result.Data, out); err != nil {
+		logError(fmt.Sprintf("Failed to parse Linear API data: %v", err))
+		return fmt.Errorf("parse linear API data: %w", err)
+	}
+
+	return nil
+}
+
+const commentCreateMutation = `mutation CommentCreate($issueId: String!, $body: String!) {
+  commentCreate(input: { issueId: $issueId, body: $body }) {
+    success
+    comment { id }
+  }
+}`
+
+func (c *linearClient) createComment(issueID, body string) (string, error) {
+	var data struct {
+		CommentCreate struct {
+			Success bool `json:"success"`
+			Comment struct {
+				ID string `json:"id"`
+			} `json:"comment"`
+		} `json:"commentCreate"`
+	}
+
+	variables := map[string]any{"issueId": issueID, "body": body}
+	if err := c.do(context.Background(), "CommentCreate", commentCreateMutation, variables, &data); err != nil {
+		return "", err
+	}
+
+	if !data.CommentCreate.Success {
+		return "", errors.New("linear API did not create the comment")
+	}
+
+	return data.CommentCreate.Comment.ID, nil
+}
+
+const issueTitleQuery = `query IssueTitle($id: String!) {
+  issue(id: $id) {
+    title
+  }
+}`
+
+func (c *linearClient) issueTitle(ctx context.Context, issueID string) (string, error) {
+	var data struct {
+		Issue *struct {
+			Title string `json:"title"`
+		} `json:"issue"`
+	}
+
+	if err := c.do(ctx, "IssueTitle", issueTitleQuery, map[string]any{"id": issueID}, &data); err != nil {
+		return "", err
+	}
+
+	if data.Issue == nil {
+		return "", fmt.Errorf("issue %s not found", issueID)
+	}
+
+	return data.Issue.Title, nil
+}
+
+const issueDetailsQuery = `query IssueDetails($id: String!) {
+  issue(id: $id) {
+    identifier
+    title
+    estimate
+    priority
+    priorityLabel
+    state { name type }
+    assignee { name }
+    labels { nodes { name } }
+    cycle { number name }
+  }
+}`
+
+func (c *linearClient) issueDetails(ctx context.Context, issueID string) (linearIssue, error) {
+	var data struct {
+		Issue *linearIssue `json:"issue"`
+	}
+
+	if err := c.do(ctx, "IssueDetails", issueDetailsQuery, map[string]any{"id": issueID}, &data); err != nil {
+		return linearIssue{}, err
+	}
+
+	if data.Issue == nil {
+		return linearIssue{}, fmt.Errorf("issue %s not found", issueID)
+	}
+
+	return *data.Issue, nil
+}
+
+const issueWorkLogQuery = `query IssueWorkLog($id: String!) {
+  issue(id: $id) {
+    estimate
+    comments(first: 250) {
+      nodes {
+        id
+        body
+        user { isMe }
+      }
+    }
+  }
+}`
+
+func (c *linearClient) issueWorkLog(ctx context.Context, issueID string) (workLog, error) {
+	var data struct {
+		Issue *struct {
+			Estimate *float64 `json:"estimate"`
+			Comments struct {
+				Nodes []struct {
+					ID   string `json:"id"`
+					Body string `json:"body"`
+					User *struct {
+						IsMe bool `json:"isMe"`
+					} `json:"user"`
+				} `json:"nodes"`
+			} `json:"comments"`
+		} `json:"issue"`
+	}
+
+	if err := c.do(ctx, "IssueWorkLog", issueWorkLogQuery, map[string]any{"id": issueID}, &data); err != nil {
+		return workLog{}, err
+	}
+
+	if data.Issue == nil {
+		return workLog{}, fmt.Errorf("issue %s not found", issueID)
+	}
+
+	out := workLog{Estimate: data.Issue.Estimate}
+	for _, n := range data.Issue.Comments.Nodes {
+		out.Comments = append(out.Comments, issueComment{
+			ID:   n.ID,
+			Body: n.Body,
+			Mine: n.User != nil && n.User.IsMe,
+		})
+	}
+
+	return out, nil
+}
+
+const issueStartInfoQuery = `query IssueStartInfo($id: String!) {
+  viewer { id }
+  issue(id: $id) {
+    state { id name type position }
+    assignee { id }
+    team {
+      states(filter: { type: { eq: "started" } }) {
+        nodes { id name type position }
+      }
+    }
+  }
+}`
+
+func (c *linearClient) issueStartInfo(ctx context.Context, issueID string) (startInfo, error) {
+	var data struct {
+		Viewer struct {
+			ID string `json:"id"`
+		} `json:"viewer"`
+		Issue *struct {
+			State    workflowState `json:"state"`
+			Assignee *struct {
+				ID string `json:"id"`
+			} `json:"assignee"`
+			Team struct {
+				States struct {
+					Nodes []workflowState `json:"nodes"`
+				} `json:"states"`
+			} `json:"team"`
+		} `json:"issue"`
+	}
+
+	if err := c.do(ctx, "IssueStartInfo", issueStartInfoQuery, map[string]any{"id": issueID}, &data); err != nil {
+		return startInfo{}, err
+	}
+
+	if data.Issue == nil {
+		return startInfo{}, fmt.Errorf("issue %s not found", issueID)
+	}
+
+	info := startInfo{
+		ViewerID:      data.Viewer.ID,
+		State:         data.Issue.State,
+		StartedStates: data.Issue.Team.States.Nodes,
+	}
+	if data.Issue.Assignee != nil {
+		info.AssigneeID = data.Issue.Assignee.ID
+	}
+
+	return info, nil
+}
+
+const issueUpdateMutation = `mutation IssueUpdate($id: String!, $input: IssueUpdateInput!) {
+  issueUpdate(id: $id, input: $input) {
+    success
+    issue { state { name } }
+  }
+}`
+
+func (c *linearClient) updateIssue(ctx context.Context, issueID string, input map[string]any) (string, error) {
+	var data struct {
+		IssueUpdate struct {
+			Success bool `json:"success"`
+			Issue   struct {
+				State struct {
+					Name string `json:"name"`
+				} `json:"state"`
+			} `json:"issue"`
+		} `json:"issueUpdate"`
+	}
+
+	variables := map[string]any{"id": issueID, "input": input}
+	if err := c.do(ctx, "IssueUpdate", issueUpdateMutation, variables, &data); err != nil {
+		return "", err
+	}
+
+	if !data.IssueUpdate.Success {
+		return "", errors.New("linear API did not update the issue")
+	}
+
+	return data.IssueUpdate.Issue.State.Name, nil
+}
+
+const searchIssuesQuery = `query SearchIssues($term: String!, $first: Int) {
+  searchIssues(term: $term, first: $first) {
+    nodes {
+      identifier
+      title
+      state { name type }
+    }
+  }
+}`
+
+func (c *linearClient) searchIssues(ctx context.Context, term string, first int) ([]linearIssue, error) {
+	var data struct {
+		SearchIssues struct {
+			Nodes []linearIssue `json:"nodes"`
+		} `json:"searchIssues"`
+	}
+
+	variables := map[string]any{"term": term, "first": first}
+	if err := c.do(ctx, "SearchIssues", searchIssuesQuery, variables, &data); err != nil {
+		return nil, err
+	}
+
+	return data.SearchIssues.Nodes, nil
+}
+
+const assignedIssuesQuery = `query AssignedIssues($first: Int) {
+  viewer {
+    assignedIssues(
+      first: $first
+      orderBy: updatedAt
+      filter: { state: { type: { in: ["started", "unstarted"] } } }
+    ) {
+      nodes {
+        identifier
+        title
+        estimate
+        state { name type position }
+        cycle { number name }
+      }
+    }
+  }
+}`
+
+func (c *linearClient) assignedIssues(ctx context.Context, first int) ([]linearIssue, error) {
+	var data struct {
+		Viewer struct {
+			AssignedIssues struct {
+				Nodes []linearIssue `json:"nodes"`
+			} `json:"assignedIssues"`
+		} `json:"viewer"`
+	}
+
+	if err := c.do(ctx, "AssignedIssues", assignedIssuesQuery, map[string]any{"first": first}, &data); err != nil {
+		return nil, err
+	}
+
+	return data.Viewer.AssignedIssues.Nodes, nil
+}
+
+func postLinearComment(issueId, value string) (string, error) {
+	client, err := newLinearAPI()
+	if err != nil {
+		return "", err
+	}
+
+	return client.createComment(issueId, value)
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeLinear struct {
+	mu       sync.Mutex
+	status   int
+	comments map[string][]string
+	calls    map[string]int
+}
+
+func newFakeLinear(t *testing.T) (*fakeLinear, *httptest.Server) {
+	t.Helper()
+
+	f := &fakeLinear{
+		status:   http.StatusOK,
+		comments: make(map[string][]string),
+		calls:    make(map[string]int),
+	}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+
+	return f, srv
+}
+
+func (f *fakeLinear) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var req graphQLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.calls[req.OperationName]++
+
+	if r.Header.Get("Authorization") != "test-key" || f.status == http.StatusUnauthorized {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"Authentication required","extensions":{"code":"AUTHENTICATION_ERROR"}}]}`))
+		return
+	}
+	if f.status != http.StatusOK {
+		w.WriteHeader(f.status)
+		return
+	}
+
+	issueID, _ := req.Variables["issueId"].(string)
+	switch req.OperationName {
+	case "CommentCreate":
+		body, _ := req.Variables["body"].(string)
+		f.comments[issueID] = append(f.comments[issueID], body)
+		_, _ = w.Write([]byte(`{"data":{"commentCreate":{"success":true,"comment":{"id":"comment-1"}}}}`))
+	case "IssueTitle":
+		if req.Variables["id"] == "UE-404" {
+			_, _ = w.Write([]byte(`{"data":{"issue":null}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"issue":{"title":"Fix login"}}}`))
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func (f *fakeLinear) setStatus(status int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.status = status
+}
+
+func (f *fakeLinear) count(operation string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.calls[operation]
+}
+
+func fakeLinearConfig(srv *httptest.Server) string {
+	return `{"api_key": "test-key", "linear_endpoint": "` + srv.URL + `"}`
+}
+
+func testSession(issueID string, d time.Duration) sessionRecord {
+	start := time.Now().Add(-d)
+	t := savedTimer{IssueID: issueID, StartTime: start, SessionStart: start, Duration: d}
+
+	return newSessionRecord(t, loadRoundingPolicy().format(d), false)
+}
+
+func TestLinearEndpoint(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		cfg  apiConfig
+		want string
+	}{
+		{name: "default", want: defaultLinearEndpoint},
+		{name: "config", cfg: apiConfig{LinearEndpoint: "http://localhost:1/graphql"}, want: "http://localhost:1/graphql"},
+		{name: "env wins", env: "http://localhost:2/graphql", cfg: apiConfig{LinearEndpoint: "http://localhost:1/graphql"}, want: "http://localhost:2/graphql"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(linearEndpointEnv, tt.env)
+			if got := linearEndpoint(tt.cfg); got != tt.want {
+				t.Errorf("linearEndpoint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubmitSessionPostsComment(t *testing.T) {
+	fake, srv := newFakeLinear(t)
+	testHome(t, fakeLinearConfig(srv))
+
+	rec := testSession("UE-1", 20*time.Minute)
+	result, ok := submitSession(rec)().(submitResultMsg)
+	if !ok {
+		t.Fatalf("submitSession() did not return a submitResultMsg")
+	}
+	if result.err != nil {
+		t.Fatalf("submitSession() error = %v", result.err)
+	}
+	if result.commentID != "comment-1" {
+		t.Errorf("commentID = %q, want comment-1", result.commentID)
+	}
+
+	if got := fake.comments["UE-1"]; len(got) != 1 || got[0] != "0:30" {
+		t.Errorf("comments = %q, want [0:30]", got)
+	}
+	if pending, failed := outboxCounts(); pending != 0 || failed != 0 {
+		t.Errorf("outbox = %d pending, %d failed, want empty", pending, failed)
+	}
+
+	sessions := loadSessions()
+	if len(sessions) != 1 || sessions[0].Status != sessionSubmitted || sessions[0].CommentID != "comment-1" {
+		t.Errorf("ledger = %+v, want one submitted session", sessions)
+	}
+}
+
+func TestSubmitSessionQueuesFailures(t *testing.T) {
+	fake, srv := newFakeLinear(t)
+	testHome(t, fakeLinearConfig(srv))
+	fake.setStatus(http.StatusInternalServerError)
+
+	rec := testSession("UE-2", 5*time.Minute)
+	result := submitSession(rec)().(submitResultMsg)
+	if result.err == nil {
+		t.Fatal("submitSession() error = nil, want an error")
+	}
+	if result.pending != 0 || result.failed != 1 {
+		t.Errorf("outbox = %d pending, %d failed, want 1 failed", result.pending, result.failed)
+	}
+
+	fake.setStatus(http.StatusOK)
+	retried := retryOutboxCmd(rec.ID)().(submitResultMsg)
+	if retried.err != nil {
+		t.Fatalf("retry error = %v", retried.err)
+	}
+	if got := fake.comments["UE-2"]; len(got) != 1 {
+		t.Errorf("comments = %q, want one", got)
+	}
+	if pending, failed := outboxCounts(); pending != 0 || failed != 0 {
+		t.Errorf("outbox = %d pending, %d failed, want empty", pending, failed)
+	}
+}
+
+func TestRetryAllReportsOutcome(t *testing.T) {
+	fake, srv := newFakeLinear(t)
+	testHome(t, fakeLinearConfig(srv))
+	fake.setStatus(http.StatusInternalServerError)
+
+	_ = submitSession(testSession("UE-4", 5*time.Minute))()
+	_ = submitSession(testSession("UE-5", 5*time.Minute))()
+
+	tests := []struct {
+		name   string
+		status int
+		want   string
+	}{
+		{name: "still failing", status: http.StatusInternalServerError, want: "Retried queued submissions: 0 posted, 2 still queued."},
+		{name: "delivered", status: http.StatusOK, want: "Retried queued submissions: 2 posted, 0 still queued."},
+		{name: "empty", status: http.StatusOK, want: "Nothing to retry."},
+	}
+
+	for _, tt := range tests {
+		fake.setStatus(tt.status)
+		msg, ok := retryOutboxCmd("")().(outboxStatusMsg)
+		if !ok {
+			t.Fatalf("%s: retryOutboxCmd() did not return an outboxStatusMsg", tt.name)
+		}
+		if msg.text != tt.want {
+			t.Errorf("%s: text = %q, want %q", tt.name, msg.text, tt.want)
+		}
+	}
+}
+
+func TestSubmitSessionAuthError(t *testing.T) {
+	fake, srv := newFakeLinear(t)
+	testHome(t, fakeLinearConfig(srv))
+	fake.setStatus(http.StatusUnauthorized)
+
+	result := submitSession(testSession("UE-3", 5*time.Minute))().(submitResultMsg)
+	if !errors.Is(result.err, errLinearAuth) {
+		t.Errorf("error = %v, want errLinearAuth", result.err)
+	}
+}
+
+func TestResolveIssueTitle(t *testing.T) {
+	fake, srv := newFakeLinear(t)
+	testHome(t, fakeLinearConfig(srv))
+
+	ctx := context.Background()
+	for range 2 {
+		title, err := resolveIssueTitle(ctx, "UE-1")
+		if err != nil {
+			t.Fatalf("resolveIssueTitle() error = %v", err)
+		}
+		if title != "Fix login" {
+			t.Errorf("title = %q, want Fix login", title)
+		}
+	}
+	if got := fake.count("IssueTitle"); got != 1 {
+		t.Errorf("IssueTitle requests = %d, want 1 (second lookup cached)", got)
+	}
+
+	if _, err := resolveIssueTitle(ctx, "UE-404"); err == nil {
+		t.Error("resolveIssueTitle(UE-404) error = nil, want not found")
+	}
+}
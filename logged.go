@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	templateActionPattern = regexp.MustCompile(`\{\{.*?\}\}`)
+	roundedActionPattern  = regexp.MustCompile(`^\{\{-?\s*\.Rounded\s*-?\}\}$`)
+)
+
+type loggedTime struct {
+	Local    time.Duration
+	Remote   time.Duration
+	Estimate *float64
+}
+
+type loggedMsg struct {
+	issueID string
+	logged  loggedTime
+}
+
+func (l loggedTime) total() time.Duration {
+	return l.Local + l.Remote
+}
+
+func (l loggedTime) String() string {
+	s := fmtHoursMinutes(l.total())
+	if estimate := formatEstimate(l.Estimate); estimate != "" {
+		s += " (" + estimate + ")"
+	}
+
+	return s
+}
+
+func localLogged(issueID string) (time.Duration, map[string]bool) {
+	policy := loadRoundingPolicy()
+	comments := make(map[string]bool)
+
+	var total time.Duration
+	for _, s := range loadSessions() {
+		if s.IssueID != issueID {
+			continue
+		}
+
+		total += sessionRounded(s, policy)
+		if s.CommentID != "" {
+			comments[s.CommentID] = true
+		}
+	}
+
+	return total, comments
+}
+
+func commentTimeMatcher() (*regexp.Regexp, error) {
+	text := defaultCommentTemplate
+	if cfg, _ := currentConfig(); cfg.CommentTemplate != "" {
+		text = cfg.CommentTemplate
+	}
+
+	var b strings.Builder
+	b.WriteString(`^\s*`)
+
+	last, found := 0, false
+	for _, loc := range templateActionPattern.FindAllStringIndex(text, -1) {
+		b.WriteString(regexp.QuoteMeta(text[last:loc[0]]))
+		if !found && roundedActionPattern.MatchString(text[loc[0]:loc[1]]) {
+			b.WriteString(`([0-9]+:[0-5][0-9])`)
+			found = true
+		} else {
+			b.WriteString(`(?s:.*?)`)
+		}
+		last = loc[1]
+	}
+	b.WriteString(regexp.QuoteMeta(text[last:]))
+	b.WriteString(`\s*$`)
+
+	if !found {
+		return nil, errors.New("comment_template does not contain {{.Rounded}}")
+	}
+
+	return regexp.Compile(b.String())
+}
+
+func issueLogged(ctx context.Context, issueID string) (loggedTime, error) {
+	var logged loggedTime
+	local, comments := localLogged(issueID)
+	logged.Local = local
+
+	if cfg, _ := currentConfig(); !cfg.LoggedFromLinear {
+		return logged, nil
+	}
+
+	client, err := newLinearAPI()
+	if err != nil {
+		return logged, err
+	}
+
+	history, err := client.issueWorkLog(ctx, issueID)
+	if err != nil {
+		return logged, err
+	}
+
+	logged.Estimate = history.Estimate
+
+	pattern, err := commentTimeMatcher()
+	if err != nil {
+		return logged, err
+	}
+
+	for _, c := range history.Comments {
+		if !c.Mine || comments[c.ID] {
+			continue
+		}
+
+		match := pattern.FindStringSubmatch(c.Body)
+		if match == nil {
+			continue
+		}
+		if d, err := parseRounded(match[1]); err == nil {
+			logged.Remote += d
+		}
+	}
+
+	return logged, nil
+}
+
+func fetchLoggedCmd(issueID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), titleLookupTimeout)
+		defer cancel()
+
+		logged, err := issueLogged(ctx, issueID)
+		if err != nil {
+			logError(fmt.Sprintf("Failed to read logged time for %s from Linear: %v", issueID, err))
+		}
+
+		return loggedMsg{issueID: issueID, logged: logged}
+	}
+}
+
+func (m model) currentIssueID() string {
+	if m.timerActive {
+		return m.input.Value()
+	}
+
+	issueID, err := parseIssueID(m.input.Value(), loadPrefix())
+	if err != nil {
+		return ""
+	}
+
+	return issueID
+}
+
+func (m model) loggedView() string {
+	if m.loggedIssue == "" || m.loggedIssue != m.currentIssueID() {
+		return ""
+	}
+
+	logged := m.logged
+	if logged.Estimate == nil && m.details != nil && m.detailsIssue == m.loggedIssue {
+		logged.Estimate = m.details.Estimate
+	}
+
+	return detailStyle.Render("logged so far: " + logged.String())
+}
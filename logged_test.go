@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestCommentTimeMatcher(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		body     string
+		want     string
+	}{
+		{name: "default", body: "1:15", want: "1:15"},
+		{name: "default with spaces", body: "  0:45\n", want: "0:45"},
+		{name: "default other text", body: "Spent 1:15 on it"},
+		{name: "default invalid minutes", body: "1:75"},
+		{name: "template", template: "Spent {{.Rounded}} on {{.IssueID}}", body: "Spent 1:15 on UE-1", want: "1:15"},
+		{name: "template multiline", template: "{{.Rounded}} logged\n{{.Title}}", body: "2:30 logged\nFix\nlogin", want: "2:30"},
+		{name: "template other text", template: "Spent {{.Rounded}} on {{.IssueID}}", body: "Worked 1:15 on UE-1"},
+		{name: "template quoted", template: "[{{.Rounded}}] (h)", body: "[0:30] (h)", want: "0:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, _ := json.Marshal(apiConfig{APIKey: "test-key", CommentTemplate: tt.template})
+			testHome(t, string(cfg))
+
+			pattern, err := commentTimeMatcher()
+			if err != nil {
+				t.Fatalf("commentTimeMatcher() error = %v", err)
+			}
+
+			got := ""
+			if match := pattern.FindStringSubmatch(tt.body); match != nil {
+				got = match[1]
+			}
+			if got != tt.want {
+				t.Errorf("match(%q) = %q, want %q", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommentTimeMatcherWithoutRounded(t *testing.T) {
+	testHome(t, `{"api_key": "test-key", "comment_template": "Worked on {{.IssueID}}"}`)
+
+	if _, err := commentTimeMatcher(); err == nil {
+		t.Error("commentTimeMatcher() error = nil, want an error")
+	}
+}
+
+func TestLoggedTimeString(t *testing.T) {
+	estimate := 3.0
+	half := 0.5
+
+	tests := []struct {
+		logged loggedTime
+		want   string
+	}{
+		{logged: loggedTime{}, want: "0:00"},
+		{logged: loggedTime{Local: time.Hour, Remote: 45 * time.Minute}, want: "1:45"},
+		{logged: loggedTime{Local: 30 * time.Minute, Estimate: &estimate}, want: "0:30 (est. 3)"},
+		{logged: loggedTime{Estimate: &half}, want: "0:00 (est. 0.5)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.logged.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	maxTitleRequests   = 4
+	titleLookupTimeout = 15 * time.Second
+)
+
+type titleCall struct {
+	done    chan struct{}
+	title   string
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+type titleLookup struct {
+	mu       sync.Mutex
+	titles   map[string]string
+	inflight map[string]*titleCall
+	slots    chan struct{}
+	seq      int
+	cancel   context.CancelFunc
+}
+
+func newTitleLookup() *titleLookup {
+	return &titleLookup{
+		titles:   make(map[string]string),
+		inflight: make(map[string]*titleCall),
+		slots:    make(chan struct{}, maxTitleRequests),
+	}
+}
+
+func (l *titleLookup) cached(issueID string) (string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	title, ok := l.titles[issueID]
+
+	return title, ok
+}
+
+func (l *titleLookup) begin() (context.Context, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.cancel != nil {
+		l.cancel()
+	}
+
+	var ctx context.Context
+	ctx, l.cancel = context.WithTimeout(context.Background(), titleLookupTimeout)
+	l.seq++
+
+	return ctx, l.seq
+}
+
+func (l *titleLookup) abandon() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.cancel != nil {
+		l.cancel()
+		l.cancel = nil
+	}
+	l.seq++
+}
+
+func (l *titleLookup) current(seq int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return seq == l.seq
+}
+
+func (l *titleLookup) title(ctx context.Context, issueID string) (string, error) {
+	l.mu.Lock()
+	if title, ok := l.titles[issueID]; ok {
+		l.mu.Unlock()
+		return title, nil
+	}
+
+	call, ok := l.inflight[issueID]
+	if !ok {
+		callCtx, cancel := context.WithTimeout(context.Background(), titleLookupTimeout)
+		call = &titleCall{done: make(chan struct{}), cancel: cancel}
+		l.inflight[issueID] = call
+		go l.run(callCtx, issueID, call)
+	}
+	call.waiters++
+	l.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.title, call.err
+	case <-ctx.Done():
+		l.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			call.cancel()
+			if l.inflight[issueID] == call {
+				delete(l.inflight, issueID)
+			}
+		}
+		l.mu.Unlock()
+
+		return "", ctx.Err()
+	}
+}
+
+func (l *titleLookup) run(ctx context.Context, issueID string, call *titleCall) {
+	defer call.cancel()
+
+	select {
+	case l.slots <- struct{}{}:
+		call.title, call.err = resolveIssueTitle(ctx, issueID)
+		<-l.slots
+	case <-ctx.Done():
+		call.err = ctx.Err()
+	}
+
+	if call.err == nil {
+		call.title = truncateTitle(call.title, titleWidth)
+	}
+
+	l.mu.Lock()
+	if l.inflight[issueID] == call {
+		delete(l.inflight, issueID)
+	}
+	if call.err == nil {
+		l.titles[issueID] = call.title
+	}
+	l.mu.Unlock()
+
+	close(call.done)
+}
+
+func (l *titleLookup) fetch(ctx context.Context, seq int, issueID string) tea.Msg {
+	title, err := l.title(ctx, issueID)
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	if err != nil {
+		logError(fmt.Sprintf("Failed to fetch issue title for %s: %v", issueID, err))
+	}
+
+	return issueTitleMsg{issueID: issueID, title: title, seq: seq}
+}
+
+func fetchIssueTitleCmd(issueID string, titles *titleLookup) tea.Cmd {
+	ctx, seq := titles.begin()
+
+	return func() tea.Msg {
+		if _, ok := titles.cached(issueID); !ok {
+			if title, fresh, ok := lookupCachedTitle(issueID); ok && !fresh {
+				return issueTitleMsg{issueID: issueID, title: truncateTitle(title, titleWidth), seq: seq, stale: true}
+			}
+		}
+
+		return titles.fetch(ctx, seq, issueID)
+	}
+}
+
+func refreshIssueTitleCmd(issueID string, titles *titleLookup) tea.Cmd {
+	ctx, seq := titles.begin()
+
+	return func() tea.Msg {
+		return titles.fetch(ctx, seq, issueID)
+	}
+}
+
+func debounceTitleCmd(d time.Duration, inputValue string) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return debounceTimerMsg{inputValue: inputValue}
+	})
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTitleLookupAfterCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"issue":{"title":"Fix login"}}}`))
+	}))
+	t.Cleanup(srv.Close)
+	testHome(t, `{"api_key": "test-key", "linear_endpoint": "`+srv.URL+`"}`)
+
+	l := newTitleLookup()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := l.title(ctx, "UE-1"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("title() error = %v, want context.Canceled", err)
+	}
+
+	title, err := l.title(context.Background(), "UE-1")
+	if err != nil || title != "Fix login" {
+		t.Errorf("title() = %q, %v, want Fix login", title, err)
+	}
+}
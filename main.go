@@ -1,7 +1,10 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"strconv"
@@ -15,7 +18,6 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/go-resty/resty/v2"
 )
 
 var version = "unknown"
@@ -38,6 +40,11 @@ var (
 	msgStyle     lipgloss.Style
 	helpStyle    lipgloss.Style
 	titleStyle   lipgloss.Style
+	detailStyle  lipgloss.Style
+	noticeStyle  lipgloss.Style
+
+	listItemStyle     lipgloss.Style
+	listSelectedStyle lipgloss.Style
 )
 
 func initializeTheme(theme string) {
@@ -65,6 +72,10 @@ func initializeTheme(theme string) {
 	msgStyle = lipgloss.NewStyle().Foreground(colorRed).Italic(true).PaddingLeft(1).PaddingTop(1)
 	helpStyle = lipgloss.NewStyle().PaddingLeft(1).PaddingTop(1)
 	titleStyle = lipgloss.NewStyle().Foreground(colorGray)
+	detailStyle = lipgloss.NewStyle().Foreground(colorGray).PaddingLeft(1)
+	noticeStyle = lipgloss.NewStyle().Foreground(colorYellow).Italic(true).PaddingLeft(1).PaddingTop(1)
+	listItemStyle = lipgloss.NewStyle().Foreground(colorLightGray).PaddingLeft(1)
+	listSelectedStyle = lipgloss.NewStyle().Foreground(colorYellow).Bold(true).PaddingLeft(1)
 }
 
 type timerMsg time.Duration
@@ -74,7 +85,10 @@ type debounceTimerMsg struct {
 }
 
 type issueTitleMsg struct {
-	title string
+	issueID string
+	title   string
+	seq     int
+	stale   bool
 }
 
 type screen int
@@ -84,6 +98,10 @@ const (
 	screenConfirmCancel
 	screenRecoverTimer
 	screenLimitedTimerSetup
+	screenTimerList
+	screenSwitchIssue
+	screenSearchIssues
+	screenMyIssues
 )
 
 type keyMap struct {
@@ -99,6 +117,14 @@ type keyMap struct {
 	LimitedTimer key.Binding
 	AddTime      key.Binding
 	SubTime      key.Binding
+	Retry        key.Binding
+	Timers       key.Binding
+	Switch       key.Binding
+	Search       key.Binding
+	MyIssues     key.Binding
+	Confirm      key.Binding
+	Deny         key.Binding
+	Back         key.Binding
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
@@ -109,59 +135,96 @@ func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Start, k.LimitedTimer, k.Submit, k.Pause, k.Resume},
 		{k.Cancel, k.AddTime, k.SubTime, k.Up, k.Down},
-		{k.Help, k.Quit},
+		{k.Search, k.MyIssues, k.Switch, k.Timers},
+		{k.Retry, k.Help, k.Quit},
 	}
 }
 
-var keys = keyMap{
-	Quit: key.NewBinding(
-		key.WithKeys("q", "ctrl+c"),
-		key.WithHelp("q", "quit"),
-	),
-	Start: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "start timer"),
-	),
-	Submit: key.NewBinding(
-		key.WithKeys("s"),
-		key.WithHelp("s", "submit time"),
-	),
-	Pause: key.NewBinding(
-		key.WithKeys("p"),
-		key.WithHelp("p", "pause"),
-	),
-	Resume: key.NewBinding(
-		key.WithKeys("r"),
-		key.WithHelp("r", "resume"),
-	),
-	Cancel: key.NewBinding(
-		key.WithKeys("c"),
-		key.WithHelp("c", "cancel timer"),
-	),
-	Up: key.NewBinding(
-		key.WithKeys("up"),
-		key.WithHelp("↑", "history up"),
-	),
-	Down: key.NewBinding(
-		key.WithKeys("down"),
-		key.WithHelp("↓", "history down"),
-	),
-	Help: key.NewBinding(
-		key.WithKeys("?"),
-		key.WithHelp("?", "more"),
-	),
-	LimitedTimer: key.NewBinding(
-		key.WithKeys("l"),
-		key.WithHelp("l", "limited timer"),
-	),
-	AddTime: key.NewBinding(
-		key.WithKeys("+"),
-		key.WithHelp("+", "add 15 min"),
-	),
-	SubTime: key.NewBinding(
-		key.WithKeys("-"),
-		key.WithHelp("-", "sub 15 min"),
-	),
+var keys = defaultKeyMap()
+
+func defaultKeyMap() keyMap {
+	return keyMap{
+		Quit: key.NewBinding(
+			key.WithKeys("q", "ctrl+c"),
+			key.WithHelp("q", "quit"),
+		),
+		Start: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "start timer"),
+		),
+		Submit: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "submit time"),
+		),
+		Pause: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "pause"),
+		),
+		Resume: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "resume"),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "cancel timer"),
+		),
+		Up: key.NewBinding(
+			key.WithKeys("up"),
+			key.WithHelp("↑", "history up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down"),
+			key.WithHelp("↓", "history down"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "more"),
+		),
+		LimitedTimer: key.NewBinding(
+			key.WithKeys("l"),
+			key.WithHelp("l", "limited timer"),
+		),
+		AddTime: key.NewBinding(
+			key.WithKeys("+"),
+			key.WithHelp("+", "add 15 min"),
+		),
+		SubTime: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", "sub 15 min"),
+		),
+		Retry: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "retry submission"),
+		),
+		Timers: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "timer list"),
+		),
+		Switch: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "switch issue"),
+		),
+		Search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "search issues"),
+		),
+		MyIssues: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "my issues"),
+		),
+		Confirm: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "yes"),
+		),
+		Deny: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "no"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc", "ctrl+c"),
+			key.WithHelp("esc", "back"),
+		),
+	}
 }
 
 type model struct {
@@ -174,6 +237,9 @@ type model struct {
 	pauseTime   time.Time
 	totalPaused time.Duration
 
+	sessionStart time.Time
+	timerAdjust  time.Duration
+
 	history      []string
 	historyIndex int
 	historyNav   bool
@@ -186,6 +252,9 @@ type model struct {
 	savedTimerValue   time.Duration
 	savedTimerLimited bool
 	savedTimerLimit   time.Duration
+	savedTimerPaused  time.Duration
+	savedTimerAdjust  time.Duration
+	savedTimerSession time.Time
 	lastSaveTime      time.Time
 
 	limitedTimer   bool
@@ -195,39 +264,58 @@ type model struct {
 	pendingIssueID string
 
 	issueTitle       string
+	details          *linearIssue
+	detailsIssue     string
+	logged           loggedTime
+	loggedIssue      string
 	lastInputValue   string
-	titleCache       map[string]string
-	debounceTimer    *time.Timer
+	titles           *titleLookup
 	debounceDuration time.Duration
+
+	outboxPending    int
+	outboxFailed     int
+	failedSubmission string
+
+	daemon bool
+
+	configErr error
+
+	branchIssue string
+	startBranch bool
+
+	parked         []savedTimer
+	listIndex      int
+	confirmDiscard string
+	switchInput    textinput.Model
+	switchPolicy   string
+
+	searchInput   textinput.Model
+	searchQuery   string
+	searchResults []linearIssue
+	searchIndex   int
+	searchLoading bool
+	searchError   string
+	searchCancel  context.CancelFunc
+
+	myIssues        []linearIssue
+	myIssuesIndex   int
+	myIssuesLoading bool
+	myIssuesError   string
+	myIssuesGroup   string
+	myIssuesCancel  context.CancelFunc
 }
 
 func (m model) Init() tea.Cmd {
-	theme := "dark"
-	b, err := os.ReadFile(os.Getenv("HOME") + "/.config/unitrack/unitrack.json")
-	if err == nil {
-		var cfg apiConfig
-		if json.Unmarshal(b, &cfg) == nil && cfg.Theme != "" {
-			theme = cfg.Theme
-		}
-	}
-	initializeTheme(theme)
-
 	m.history = loadHistory()
 	m.screen = screenMain
 	m.keys = keys
 	m.debounceDuration = 500 * time.Millisecond
 
 	m.help = help.New()
-	m.help.Styles.ShortKey = lipgloss.NewStyle().Foreground(colorLightGray)
-	m.help.Styles.ShortDesc = lipgloss.NewStyle().Foreground(colorGray)
-	m.help.Styles.ShortSeparator = lipgloss.NewStyle().Foreground(colorGray)
-	m.help.Styles.FullKey = lipgloss.NewStyle().Foreground(colorLightGray)
-	m.help.Styles.FullDesc = lipgloss.NewStyle().Foreground(colorGray)
-	m.help.Styles.FullSeparator = lipgloss.NewStyle().Foreground(colorGray)
-
 	m.spinner = spinner.New()
 	m.spinner.Spinner = spinner.Dot
-	m.spinner.Style = lipgloss.NewStyle().Foreground(colorOrange)
+	cfg, _ := currentConfig()
+	m.applyTheme(cfg.Theme)
 
 	m.limitInput = textinput.New()
 	m.limitInput.Placeholder = "15"
@@ -237,16 +325,138 @@ func (m model) Init() tea.Cmd {
 	m.progressBar = progress.New(progress.WithDefaultGradient())
 	m.progressBar.Width = 40
 
-	return textinput.Blink
+	cmds := []tea.Cmd{textinput.Blink, m.outboxCmd(), tickOutbox(), tickConfig()}
+	if m.daemon {
+		cmds = append(cmds, tickTimer(), daemonCmd(daemonRequest{Command: "status"}))
+	}
+	if m.branchIssue != "" {
+		if m.startBranch {
+			cmds = append(cmds, startIssueCmd(m.branchIssue))
+		}
+		cmds = append(cmds, fetchIssueTitleCmd(m.branchIssue, m.titles))
+	}
+
+	return tea.Batch(cmds...)
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.update(msg)
+	if nm, ok := next.(model); ok {
+		return nm.followActiveIssue(cmd)
+	}
+
+	return next, cmd
+}
+
+func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch message := msg.(type) {
+	case issueDetailsMsg:
+		return m.applyIssueDetails(message), nil
+
+	case issueWorkflowMsg:
+		m.message = strings.TrimSpace(m.message + " " + message.text)
+
+		return m, nil
+
+	case loggedMsg:
+		if message.issueID == m.currentIssueID() {
+			m.logged = message.logged
+			m.loggedIssue = message.issueID
+		}
+
+		return m, nil
+
+	case outboxStatusMsg:
+		m.outboxPending = message.pending
+		m.outboxFailed = message.failed
+		if message.text != "" {
+			m.message = message.text
+		}
+
+		return m, nil
+
+	case outboxTickMsg:
+		return m, tea.Batch(m.outboxCmd(), tickOutbox())
+
+	case configTickMsg:
+		return m, tea.Batch(reloadConfigCmd(), tickConfig())
+
+	case configReloadMsg:
+		m.applyConfig(message.cfg, message.err)
+		if message.err != nil {
+			m.message = "Configuration reloaded with errors."
+		} else {
+			m.message = "Configuration reloaded."
+		}
+
+		return m, nil
+
+	case timerMsg:
+		if m.daemon {
+			return m, tea.Batch(tickTimer(), m.spinner.Tick, daemonCmd(daemonRequest{Command: "status"}))
+		}
+
+	case daemonResultMsg:
+		return m.applyDaemonResult(message)
+
+	case startIssueMsg:
+		if m.timerActive || m.screen != screenMain {
+			return m, nil
+		}
+
+		return m.beginTimer(message.issueID)
+
+	case submitResultMsg:
+		m.outboxPending = message.pending
+		m.outboxFailed = message.failed
+
+		if message.err == nil {
+			m.failedSubmission = ""
+			m.message = fmt.Sprintf(
+				"Posted %s to Linear for issue %s (comment %s).",
+				message.rounded,
+				message.issueID,
+				message.commentID,
+			)
+
+			return m, nil
+		}
+
+		if errors.Is(message.err, errOutboxEntryGone) {
+			m.failedSubmission = ""
+			m.message = "Submission was already delivered."
+
+			return m, nil
+		}
+
+		m.failedSubmission = message.id
+		if errors.Is(message.err, errLinearAuth) {
+			m.message = fmt.Sprintf(
+				"Posting %s for %s failed: %v. Check that your API key has Read and Create comments scopes. Press '%s' to retry.",
+				message.rounded,
+				message.issueID,
+				message.err,
+				m.keys.Retry.Help().Key,
+			)
+		} else {
+			m.message = fmt.Sprintf(
+				"Posting %s for %s failed: %v. Queued for retry, press '%s' to retry now.",
+				message.rounded,
+				message.issueID,
+				message.err,
+				m.keys.Retry.Help().Key,
+			)
+		}
+
+		return m, nil
+	}
+
 	switch m.screen {
 	case screenMain:
 		switch message := msg.(type) {
 		case tea.KeyMsg:
-			switch message.String() {
-			case "up":
+			switch {
+			case key.Matches(message, m.keys.Up):
 				if !m.timerActive && len(m.history) > 0 {
 					if !m.historyNav {
 						m.historyIndex = len(m.history) - 1
@@ -255,49 +465,61 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.historyIndex--
 					}
 					m.input.SetValue(m.history[m.historyIndex])
+
+					return m.showHistoryTitle()
 				}
 
 				return m, nil
 
-			case "down":
+			case key.Matches(message, m.keys.Down):
 				if !m.timerActive && m.historyNav && len(m.history) > 0 {
 					if m.historyIndex < len(m.history)-1 {
 						m.historyIndex++
 						m.input.SetValue(m.history[m.historyIndex])
-					} else {
-						m.input.SetValue("")
-						m.historyNav = false
+
+						return m.showHistoryTitle()
 					}
+
+					m.input.SetValue("")
+					m.lastInputValue = ""
+					m.issueTitle = ""
+					m.historyNav = false
 				}
 
 				return m, nil
 
-			case "ctrl+c", "q":
+			case key.Matches(message, m.keys.Quit):
 				return m, tea.Quit
 
-			case "?":
+			case key.Matches(message, m.keys.Retry):
+				if m.failedSubmission != "" || m.outboxFailed > 0 {
+					m.message = "Retrying submission..."
+					id := m.failedSubmission
+					m.failedSubmission = ""
+
+					if m.daemon {
+						return m, daemonCmd(daemonRequest{Command: "retry", SessionID: id})
+					}
+
+					return m, retryOutboxCmd(id)
+				}
+
+				return m, nil
+
+			case key.Matches(message, m.keys.Help):
 				m.help.ShowAll = !m.help.ShowAll
 
 				return m, nil
 
-			case "l":
-				val := m.input.Value()
-				fullId := val
+			case key.Matches(message, m.keys.LimitedTimer):
+				if !m.timerActive {
+					fullId, err := parseIssueID(m.input.Value(), loadPrefix())
+					if err != nil {
+						m.message = issueIDErrorMessage(err)
 
-				b, err := os.ReadFile(os.Getenv("HOME") + "/.config/unitrack/unitrack.json")
-				prefix := "UE"
-				if err == nil {
-					var cfg apiConfig
-					if json.Unmarshal(b, &cfg) == nil && cfg.Prefix != "" {
-						prefix = cfg.Prefix
+						return m, nil
 					}
-				}
 
-				if !strings.HasPrefix(val, prefix+"-") && val != "" {
-					fullId = prefix + "-" + val
-				}
-
-				if !m.timerActive && val != "" {
 					m.pendingIssueID = fullId
 					m.screen = screenLimitedTimerSetup
 					m.limitInput.Focus()
@@ -305,26 +527,30 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, textinput.Blink
 				}
 
-				if val == "" && !m.timerActive {
-					m.message = "Issue ID cannot be empty."
-
-					return m, nil
-				}
-
-			case "p":
+			case key.Matches(message, m.keys.Pause):
 				if m.timerActive && !m.timerPaused {
+					if m.daemon {
+						return m, daemonCmd(daemonRequest{Command: "pause"})
+					}
+
 					m.timerPaused = true
 					m.pauseTime = time.Now()
-					m.message = "Press 'r' to resume."
+					m.message = fmt.Sprintf("Press '%s' to resume.", m.keys.Resume.Help().Key)
+					saveTimer(m.snapshot())
 
 					return m, nil
 				}
 
-			case "r":
+			case key.Matches(message, m.keys.Resume):
 				if m.timerActive && m.timerPaused {
+					if m.daemon {
+						return m, daemonCmd(daemonRequest{Command: "resume"})
+					}
+
 					m.timerPaused = false
 					m.totalPaused += time.Since(m.pauseTime)
 					m.message = "Timer resumed."
+					saveTimer(m.snapshot())
 
 					if m.limitedTimer {
 						return m, tickTimer()
@@ -333,54 +559,50 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 
-			case "s":
+			case key.Matches(message, m.keys.Submit):
 				if m.timerActive {
-					ceiled := ceilToQuarter(m.timerValue)
-					issueId := m.input.Value()
-
-					b, err := os.ReadFile(os.Getenv("HOME") + "/.config/unitrack/unitrack.json")
-					prefix := "UE"
-					if err == nil {
-						var cfg apiConfig
-						if json.Unmarshal(b, &cfg) == nil && cfg.Prefix != "" {
-							prefix = cfg.Prefix
-						}
+					if m.daemon {
+						return m, daemonCmd(daemonRequest{Command: "submit"})
 					}
 
-					if !strings.HasPrefix(issueId, prefix+"-") && issueId != "" {
-						issueId = prefix + "-" + issueId
-					}
+					return m.submitActive(false)
+				}
 
-					m.message = fmt.Sprintf("Posting %s to Linear for issue %s...", ceiled, issueId)
-					m.timerActive = false
-					m.timerPaused = false
+			case key.Matches(message, m.keys.Timers):
+				if m.inputIdle() {
+					m.listIndex = 0
+					m.screen = screenTimerList
 
-					logError(fmt.Sprintf(
-						"SUBMIT ISSUE: %s TIME: %s CEIL: %s",
-						issueId,
-						fmtDuration(m.timerValue),
-						ceiled,
-					))
+					return m, nil
+				}
 
-					deleteSavedTimer(issueId)
+			case key.Matches(message, m.keys.Switch):
+				if m.timerActive {
+					m.switchPolicy = loadSwitchPolicy()
+					m.switchInput.SetValue("")
+					m.switchInput.Focus()
+					m.screen = screenSwitchIssue
 
-					go postLinearComment(issueId, ceiled)
+					return m, textinput.Blink
+				}
 
-					m.history = loadHistory()
-					m.input.SetValue("")
-					m.issueTitle = ""
-					m.input.Focus()
+			case key.Matches(message, m.keys.Search):
+				if m.inputIdle() {
+					return m.openSearch()
+				}
 
-					return m, textinput.Blink
+			case key.Matches(message, m.keys.MyIssues):
+				if m.inputIdle() {
+					return m.openMyIssues()
 				}
 
-			case "c":
+			case key.Matches(message, m.keys.Cancel):
 				if m.timerActive {
 					m.screen = screenConfirmCancel
 					return m, nil
 				}
 
-			case "+":
+			case key.Matches(message, m.keys.AddTime):
 				if m.timerActive {
 					fifteenMinutes := 15 * time.Minute
 					if m.limitedTimer {
@@ -390,144 +612,72 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							return m, nil
 						}
 					}
+					if m.daemon {
+						return m, daemonCmd(daemonRequest{Command: "adjust", Delta: fifteenMinutes})
+					}
 					m.timerStart = m.timerStart.Add(-fifteenMinutes)
 					m.timerValue += fifteenMinutes
+					m.timerAdjust += fifteenMinutes
 					m.message = "Added 15 minutes to timer."
+					saveTimer(m.snapshot())
 					return m, nil
 				}
 
-			case "-":
+			case key.Matches(message, m.keys.SubTime):
 				if m.timerActive {
 					fifteenMinutes := 15 * time.Minute
 					if m.timerValue >= fifteenMinutes {
+						if m.daemon {
+							return m, daemonCmd(daemonRequest{Command: "adjust", Delta: -fifteenMinutes})
+						}
 						m.timerStart = m.timerStart.Add(fifteenMinutes)
 						m.timerValue -= fifteenMinutes
+						m.timerAdjust -= fifteenMinutes
 						m.message = "Subtracted 15 minutes from timer."
+						saveTimer(m.snapshot())
 					} else {
 						m.message = "Cannot subtract 15 minutes: timer would go below 15 minutes."
 					}
 					return m, nil
 				}
 
-			case "enter":
-				val := m.input.Value()
-				fullId := val
-
-				b, err := os.ReadFile(os.Getenv("HOME") + "/.config/unitrack/unitrack.json")
-				prefix := "UE"
-				if err == nil {
-					var cfg apiConfig
-					if json.Unmarshal(b, &cfg) == nil && cfg.Prefix != "" {
-						prefix = cfg.Prefix
-					}
-				}
-
-				if !strings.HasPrefix(val, prefix+"-") && val != "" {
-					fullId = prefix + "-" + val
-				}
-
-				if !m.timerActive && val != "" {
-					if saved := loadSavedTimer(fullId); saved != nil {
-						m.savedTimerIssue = fullId
-						m.savedTimerValue = saved.Duration
-						m.savedTimerLimited = saved.LimitedTimer
-						m.savedTimerLimit = saved.TimerLimit
-						m.screen = screenRecoverTimer
+			case key.Matches(message, m.keys.Start):
+				if !m.timerActive {
+					fullId, err := parseIssueID(m.input.Value(), loadPrefix())
+					if err != nil {
+						m.message = issueIDErrorMessage(err)
 
 						return m, nil
 					}
 
-					found := false
-					for _, h := range m.history {
-						if h == fullId {
-							found = true
-							break
-						}
-					}
-					if !found {
-						m.history = append(m.history, fullId)
-						saveHistory(m.history)
-					}
-
 					m.input.SetValue(fullId)
-					m.historyNav = false
-					m.timerActive = true
-					m.timerPaused = false
-					m.timerStart = time.Now()
-					m.input.Blur()
-					m.timerValue = 0
-					m.totalPaused = 0
-					m.message = "Timer started."
-					m.lastSaveTime = time.Now()
-
-					return m, tea.Batch(tickTimer(), m.spinner.Tick)
-				}
+					m.lastInputValue = fullId
 
-				if val == "" && !m.timerActive {
-					m.message = "Issue ID cannot be empty."
-
-					return m, nil
+					return m.beginTimer(fullId)
 				}
 			}
 
 		case timerMsg:
 			if m.timerActive && !m.timerPaused {
-				m.timerValue = time.Since(m.timerStart) - m.totalPaused
-				if m.limitedTimer && m.timerValue >= m.timerLimit {
-					m.timerValue = m.timerLimit
-					ceiled := ceilToQuarter(m.timerValue)
-					issueId := m.input.Value()
-					m.message = fmt.Sprintf("Time limit reached! Posting %s to Linear for issue %s...", ceiled, issueId)
-					m.timerActive = false
-					m.timerPaused = false
-					m.limitedTimer = false
-					logEntry := fmt.Sprintf("AUTO-SUBMIT ISSUE: %s TIME: %s CEIL: %s", issueId, fmtDuration(m.timerValue), ceiled)
-					logError(logEntry)
-					deleteSavedTimer(issueId)
-					go postLinearComment(issueId, ceiled)
-					go showTimerNotification(issueId, ceiled)
-					m.history = loadHistory()
-					m.input.SetValue("")
-					m.issueTitle = ""
-					m.input.Focus()
-
-					return m, textinput.Blink
-				}
-
-				if time.Since(m.lastSaveTime) >= time.Minute {
-					issueId := m.input.Value()
-					saveTimer(issueId, m.timerValue, m.timerStart, m.totalPaused, m.limitedTimer, m.timerLimit)
-					m.lastSaveTime = time.Now()
-				}
-
-				return m, tea.Batch(tickTimer(), m.spinner.Tick)
+				return m.tick()
 			}
 
 		case issueTitleMsg:
+			if !m.titles.current(message.seq) {
+				return m, nil
+			}
 			m.issueTitle = message.title
+			if message.stale {
+				return m, refreshIssueTitleCmd(message.issueID, m.titles)
+			}
 			return m, nil
 
 		case debounceTimerMsg:
 			if message.inputValue == m.input.Value() {
-				b, err := os.ReadFile(os.Getenv("HOME") + "/.config/unitrack/unitrack.json")
-				prefix := "UE"
-				if err == nil {
-					var cfg apiConfig
-					if json.Unmarshal(b, &cfg) == nil && cfg.Prefix != "" {
-						prefix = cfg.Prefix
-					}
-				}
-
-				fullId := message.inputValue
-				if !strings.HasPrefix(message.inputValue, prefix+"-") && message.inputValue != "" {
-					fullId = prefix + "-" + message.inputValue
-				}
-
-				if fullId != "" && len(fullId) > len(prefix+"-") {
-					return m, fetchIssueTitleCmd(fullId, m.titleCache)
-				} else {
-					m.issueTitle = ""
+				if fullId, err := parseIssueID(message.inputValue, loadPrefix()); err == nil {
+					return m, tea.Batch(fetchIssueTitleCmd(fullId, m.titles), fetchLoggedCmd(fullId))
 				}
+				m.issueTitle = ""
 			}
 			return m, nil
 		}
@@ -540,42 +690,49 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if currentValue != m.lastInputValue {
 				m.lastInputValue = currentValue
 
-				if m.debounceTimer != nil {
-					m.debounceTimer.Stop()
-				}
-
-				m.debounceTimer = time.NewTimer(m.debounceDuration)
-				debounceCmd := func() tea.Msg {
-					<-m.debounceTimer.C
-					return debounceTimerMsg{inputValue: currentValue}
-				}
-				cmd = tea.Batch(cmd, debounceCmd)
+				m.titles.abandon()
+				cmd = tea.Batch(cmd, debounceTitleCmd(m.debounceDuration, currentValue))
 			}
 		}
 		m.spinner, _ = m.spinner.Update(msg)
 
 		return m, cmd
 
+	case screenTimerList:
+		return m.updateTimerList(msg)
+
+	case screenSwitchIssue:
+		return m.updateSwitchIssue(msg)
+
+	case screenSearchIssues:
+		return m.updateSearch(msg)
+
+	case screenMyIssues:
+		return m.updateMyIssues(msg)
+
 	case screenConfirmCancel:
 		switch message := msg.(type) {
 		case tea.KeyMsg:
-			if message.String() == "y" {
+			if key.Matches(message, m.keys.Confirm) {
+				if m.daemon {
+					m.screen = screenMain
+
+					return m, daemonCmd(daemonRequest{Command: "cancel"})
+				}
+
 				issueId := m.input.Value()
 				deleteSavedTimer(issueId)
-				m.timerActive = false
-				m.timerPaused = false
-				m.limitedTimer = false
-				m.limitedTimer = false
+				m.resetTimer()
 				m.screen = screenMain
 				m.message = "Timer cancelled."
-				m.input.SetValue("")
-				m.issueTitle = ""
-				m.input.Focus()
 
 				return m, textinput.Blink
-			} else if message.String() == "n" {
+			} else if key.Matches(message, m.keys.Deny) {
 				m.screen = screenMain
 				m.message = "Cancel aborted."
+				if m.daemon {
+					return m, m.spinner.Tick
+				}
 
 				return m, tea.Batch(tickTimer(), m.spinner.Tick)
 			}
@@ -586,25 +743,33 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case screenRecoverTimer:
 		switch message := msg.(type) {
 		case tea.KeyMsg:
-			if message.String() == "y" {
+			if key.Matches(message, m.keys.Confirm) {
 				m.timerActive = true
 				m.timerPaused = false
 				m.limitedTimer = m.savedTimerLimited
 				m.timerLimit = m.savedTimerLimit
-				m.timerStart = time.Now().Add(-m.savedTimerValue)
+				m.timerStart = time.Now().Add(-m.savedTimerValue - m.savedTimerPaused)
+				m.sessionStart = m.savedTimerSession
+				m.timerAdjust = m.savedTimerAdjust
 				m.input.Blur()
 				m.timerValue = m.savedTimerValue
-				m.totalPaused = 0
+				m.totalPaused = m.savedTimerPaused
+				m.input.SetValue(m.savedTimerIssue)
+				m.removeParked(m.savedTimerIssue)
 				m.message = fmt.Sprintf("Resumed timer at %s", fmtDuration(m.savedTimerValue))
 				m.screen = screenMain
 				m.lastSaveTime = time.Now()
 
-				return m, tea.Batch(tickTimer(), m.spinner.Tick)
-			} else if message.String() == "n" {
+				return m, tea.Batch(tickTimer(), m.spinner.Tick, startWorkflowCmd(m.savedTimerIssue))
+			} else if key.Matches(message, m.keys.Deny) {
 				deleteSavedTimer(m.savedTimerIssue)
+				m.input.SetValue(m.savedTimerIssue)
+				m.removeParked(m.savedTimerIssue)
 				m.timerActive = true
 				m.timerPaused = false
 				m.timerStart = time.Now()
+				m.sessionStart = m.timerStart
+				m.timerAdjust = 0
 				m.input.Blur()
 				m.timerValue = 0
 				m.totalPaused = 0
@@ -612,7 +777,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.screen = screenMain
 				m.lastSaveTime = time.Now()
 
-				return m, tea.Batch(tickTimer(), m.spinner.Tick)
+				return m, tea.Batch(tickTimer(), m.spinner.Tick, startWorkflowCmd(m.savedTimerIssue))
 			}
 		}
 
@@ -621,8 +786,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case screenLimitedTimerSetup:
 		switch message := msg.(type) {
 		case tea.KeyMsg:
-			switch message.String() {
-			case "enter":
+			switch {
+			case key.Matches(message, m.keys.Start):
 				minutesStr := m.limitInput.Value()
 				if minutesStr == "" {
 					m.message = "Please enter a number of minutes."
@@ -634,31 +799,20 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, nil
 				}
 				m.timerLimit = time.Duration(minutes) * time.Minute
-				m.limitedTimer = true
-				found := false
-				for _, h := range m.history {
-					if h == m.pendingIssueID {
-						found = true
-						break
-					}
-				}
-				if !found {
-					m.history = append(m.history, m.pendingIssueID)
-					saveHistory(m.history)
+				if m.daemon {
+					m.screen = screenMain
+					m.addHistory(m.pendingIssueID)
+					m.historyNav = false
+
+					return m, daemonCmd(daemonRequest{Command: "start", IssueID: m.pendingIssueID, Limit: m.timerLimit})
 				}
-				m.input.SetValue(m.pendingIssueID)
-				m.historyNav = false
-				m.timerActive = true
-				m.timerPaused = false
-				m.timerStart = time.Now()
-				m.input.Blur()
-				m.timerValue = 0
-				m.totalPaused = 0
-				m.message = fmt.Sprintf("Limited timer started for %d minutes", minutes)
+				m.limitedTimer = true
+				m.startTimer(m.pendingIssueID)
+				m.message = fmt.Sprintf("Limited timer started for %d minutes.", minutes)
 				m.screen = screenMain
-				return m, tea.Batch(tickTimer(), m.spinner.Tick)
+				return m, tea.Batch(tickTimer(), m.spinner.Tick, startWorkflowCmd(m.pendingIssueID))
 
-			case "ctrl+c", "q", "esc":
+			case key.Matches(message, m.keys.Back, m.keys.Quit):
 				m.screen = screenMain
 				m.limitInput.SetValue("")
 				m.message = "Limited timer cancelled."
@@ -696,7 +850,7 @@ func (m model) View() string {
 				titleStyle.Render(m.issueTitle),
 			)
 		}
-		shortcutsHelp := helpStyle.Render(m.help.View(keys))
+		shortcutsHelp := helpStyle.Render(m.help.View(m.keys))
 
 		var timer string
 		if m.timerActive {
@@ -747,19 +901,64 @@ func (m model) View() string {
 
 			var viewElements []string
 			viewElements = append(viewElements, titleLine, input)
-			viewElements = append(viewElements, timer, msgStyle.Render(m.message), shortcutsHelp)
+			if details := m.issueDetailsView(); details != "" {
+				viewElements = append(viewElements, details)
+			}
+			if logged := m.loggedView(); logged != "" {
+				viewElements = append(viewElements, logged)
+			}
+			viewElements = append(viewElements, timer, msgStyle.Render(m.message))
+			if parked := m.parkedView(); parked != "" {
+				viewElements = append(viewElements, parked)
+			}
+			if outbox := m.outboxView(); outbox != "" {
+				viewElements = append(viewElements, outbox)
+			}
+			if cfgErr := m.configView(); cfgErr != "" {
+				viewElements = append(viewElements, cfgErr)
+			}
+			viewElements = append(viewElements, shortcutsHelp)
 
 			return lipgloss.JoinVertical(lipgloss.Top, viewElements...)
 		}
 
 		var viewElements []string
 		viewElements = append(viewElements, titleLine, input)
-		viewElements = append(viewElements, msgStyle.Render(m.message), shortcutsHelp)
+		if logged := m.loggedView(); logged != "" {
+			viewElements = append(viewElements, logged)
+		}
+		viewElements = append(viewElements, msgStyle.Render(m.message))
+		if parked := m.parkedView(); parked != "" {
+			viewElements = append(viewElements, parked)
+		}
+		if outbox := m.outboxView(); outbox != "" {
+			viewElements = append(viewElements, outbox)
+		}
+		if cfgErr := m.configView(); cfgErr != "" {
+			viewElements = append(viewElements, cfgErr)
+		}
+		viewElements = append(viewElements, shortcutsHelp)
 
 		return lipgloss.JoinVertical(lipgloss.Top, viewElements...)
 
+	case screenTimerList:
+		return m.timerListView()
+
+	case screenSwitchIssue:
+		return m.switchIssueView()
+
+	case screenSearchIssues:
+		return m.searchView()
+
+	case screenMyIssues:
+		return m.myIssuesView()
+
 	case screenConfirmCancel:
-		return headerBar.Render("Cancel timer? Press y to confirm, n to abort.")
+		return headerBar.Render(fmt.Sprintf(
+			"Cancel timer? Press %s to confirm, %s to abort.",
+			m.keys.Confirm.Help().Key,
+			m.keys.Deny.Help().Key,
+		))
 
 	case screenRecoverTimer:
 		var timerInfo string
@@ -782,7 +981,11 @@ func (m model) View() string {
 		return lipgloss.JoinHorizontal(
 			lipgloss.Top,
 			headerBar.Render(timerInfo),
-			headerBar.Render("Continue from saved time? Press y to continue, n to start fresh."),
+			headerBar.Render(fmt.Sprintf(
+				"Continue from saved time? Press %s to continue, %s to start fresh.",
+				m.keys.Confirm.Help().Key,
+				m.keys.Deny.Help().Key,
+			)),
 		)
 
 	case screenLimitedTimerSetup:
@@ -810,142 +1013,121 @@ func (m model) View() string {
 	return ""
 }
 
-func tickTimer() tea.Cmd {
-	return tea.Tick(time.Second, func(time.Time) tea.Msg {
-		return timerMsg(time.Second)
-	})
-}
-
-func fetchIssueTitleCmd(issueId string, cache map[string]string) tea.Cmd {
-	return func() tea.Msg {
-		return issueTitleMsg{title: fetchIssueTitle(issueId, cache)}
+func (m model) outboxView() string {
+	if m.outboxPending == 0 && m.outboxFailed == 0 {
+		return ""
 	}
-}
 
-func fmtDuration(d time.Duration) string {
-	t := int(d.Seconds())
-	h := t / 3600
-	m := (t % 3600) / 60
-	s := t % 60
-
-	return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+	return noticeStyle.Render(fmt.Sprintf(
+		"Outbox: %d pending, %d failed (retrying in background)",
+		m.outboxPending,
+		m.outboxFailed,
+	))
 }
 
-func ceilToQuarter(d time.Duration) string {
-	tm := d.Minutes()
-	quar := int((tm+14.999)/15) * 15
-	h := quar / 60
-	m := quar % 60
-
-	return fmt.Sprintf("%d:%02d", h, m)
-}
+func (m model) configView() string {
+	if m.configErr == nil {
+		return ""
+	}
 
-type apiConfig struct {
-	APIKey          string `json:"api_key"`
-	Prefix          string `json:"prefix"`
-	TimerExpireDays int    `json:"timer_expire_days,omitempty"`
-	Theme           string `json:"theme,omitempty"`
+	return noticeStyle.Render("Config: " + configErrorText(m.configErr))
 }
 
-func postLinearComment(issueId, value string) {
-	b, err := os.ReadFile(os.Getenv("HOME") + "/.config/unitrack/unitrack.json")
-	if err != nil {
-		logError(fmt.Sprintf("Failed to read config: %v", err))
-		return
-	}
+func (m *model) applyConfig(cfg apiConfig, err error) {
+	m.configErr = err
+	m.applyTheme(cfg.Theme)
 
-	var cfg apiConfig
-	err = json.Unmarshal(b, &cfg)
-	if err != nil || cfg.APIKey == "" {
-		logError(fmt.Sprintf("Failed to parse config or missing key: %v", err))
-		return
+	prefix := defaultPrefix
+	if cfg.Prefix != "" {
+		prefix = cfg.Prefix
 	}
+	m.input.Placeholder = prefix + "-1234"
+	m.switchInput.Placeholder = prefix + "-1234"
 
-	mutation := `mutation CommentCreate { commentCreate(input: { issueId: "` + issueId + `", body: "` + value + `" }) { comment { id } } }`
-	resp, err := resty.New().R().
-		SetHeader("Authorization", cfg.APIKey).
-		SetHeader("Content-Type", "application/json").
-		SetBody(map[string]string{"query": mutation}).
-		Post("https://api.linear.app/graphql")
-	if resp == nil {
-		logError("Linear API response is nil")
-		return
+	bindings, keysErr := newKeyBindings(cfg.KeyPreset, cfg.Keys)
+	if keysErr != nil {
+		bindings = defaultKeyBindings()
 	}
+	applyKeyBindings(bindings)
+	m.keys = keys
+}
 
-	logError(fmt.Sprintf("Linear API response status: %d, response: %s", resp.StatusCode(), resp.String()))
-
-	if err != nil {
-		logError(fmt.Sprintf("Linear API error: %v", err))
-		return
+func (m *model) applyTheme(theme string) {
+	if theme == "" {
+		theme = "dark"
 	}
+	initializeTheme(theme)
 
-	if resp.StatusCode() != 200 {
-		logError(fmt.Sprintf("Linear API returned non-200: %d. Response: %s", resp.StatusCode(), resp.String()))
-	}
+	m.help.Styles.ShortKey = lipgloss.NewStyle().Foreground(colorLightGray)
+	m.help.Styles.ShortDesc = lipgloss.NewStyle().Foreground(colorGray)
+	m.help.Styles.ShortSeparator = lipgloss.NewStyle().Foreground(colorGray)
+	m.help.Styles.FullKey = lipgloss.NewStyle().Foreground(colorLightGray)
+	m.help.Styles.FullDesc = lipgloss.NewStyle().Foreground(colorGray)
+	m.help.Styles.FullSeparator = lipgloss.NewStyle().Foreground(colorGray)
+	m.spinner.Style = lipgloss.NewStyle().Foreground(colorOrange)
 }
 
-func fetchIssueTitle(issueId string, cache map[string]string) string {
-	if cachedTitle, exists := cache[issueId]; exists {
-		return cachedTitle
-	}
+func (m model) inputIdle() bool {
+	return m.timerActive || m.input.Value() == ""
+}
 
-	b, err := os.ReadFile(os.Getenv("HOME") + "/.config/unitrack/unitrack.json")
-	if err != nil {
-		logError(fmt.Sprintf("Failed to read config: %v", err))
-		return ""
-	}
+func (m model) tick() (model, tea.Cmd) {
+	m.timerValue = time.Since(m.timerStart) - m.totalPaused
+	if m.limitedTimer && m.timerValue >= m.timerLimit {
+		m.timerValue = m.timerLimit
 
-	var cfg apiConfig
-	err = json.Unmarshal(b, &cfg)
-	if err != nil || cfg.APIKey == "" {
-		logError(fmt.Sprintf("Failed to parse config or missing key: %v", err))
-		return ""
+		return m.submitActive(true)
 	}
 
-	query := `query { issue(id: "` + issueId + `") { title } }`
-	resp, err := resty.New().R().
-		SetHeader("Authorization", cfg.APIKey).
-		SetHeader("Content-Type", "application/json").
-		SetBody(map[string]string{"query": query}).
-		Post("https://api.linear.app/graphql")
-	if err != nil || resp == nil || resp.StatusCode() != 200 {
-		logError(fmt.Sprintf("Linear API error: %v", err))
-		return ""
+	if time.Since(m.lastSaveTime) >= time.Minute {
+		saveTimer(m.snapshot())
+		m.lastSaveTime = time.Now()
 	}
 
-	logError(fmt.Sprintf("Linear API response status: %d, response: %s", resp.StatusCode(), resp.String()))
-
-	var result map[string]interface{}
-	if err = json.Unmarshal(resp.Body(), &result); err != nil {
-		logError(fmt.Sprintf("Failed to parse Linear API response: %v", err))
-		return ""
-	}
+	return m, tea.Batch(tickTimer(), m.spinner.Tick)
+}
 
-	data, ok := result["data"].(map[string]interface{})
-	if !ok {
-		logError("Linear API response does not contain data")
-		return ""
-	}
+func tickTimer() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return timerMsg(time.Second)
+	})
+}
 
-	issue, ok := data["issue"].(map[string]interface{})
-	if !ok {
-		logError("Linear API response does not contain issue")
-		return ""
+func (m model) showHistoryTitle() (model, tea.Cmd) {
+	issueID := m.input.Value()
+	m.lastInputValue = issueID
+	m.issueTitle = ""
+	if title, _, ok := lookupCachedTitle(issueID); ok {
+		m.issueTitle = truncateTitle(title, titleWidth)
 	}
 
-	title, ok := issue["title"].(string)
-	if !ok {
-		logError("Linear API response does not contain issue title")
-		return ""
-	}
+	return m, tea.Batch(fetchIssueTitleCmd(issueID, m.titles), fetchLoggedCmd(issueID))
+}
 
-	if len(title) > 70 {
-		title = fmt.Sprintf("%s...", title[:67])
-	}
+func fmtDuration(d time.Duration) string {
+	t := int(d.Seconds())
+	h := t / 3600
+	m := (t % 3600) / 60
+	s := t % 60
 
-	cache[issueId] = title
+	return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+}
 
-	return title
+type apiConfig struct {
+	APIKey           string              `json:"api_key"`
+	Prefix           string              `json:"prefix"`
+	TimerExpireDays  int                 `json:"timer_expire_days,omitempty"`
+	Theme            string              `json:"theme,omitempty"`
+	LinearEndpoint   string              `json:"linear_endpoint,omitempty"`
+	Rounding         *roundingConfig     `json:"rounding,omitempty"`
+	SwitchPolicy     string              `json:"switch_policy,omitempty"`
+	CommentTemplate  string              `json:"comment_template,omitempty"`
+	TitleCacheHours  int                 `json:"title_cache_hours,omitempty"`
+	LoggedFromLinear bool                `json:"logged_from_linear,omitempty"`
+	MoveOnStart      bool                `json:"move_on_start,omitempty"`
+	AssignOnStart    bool                `json:"assign_on_start,omitempty"`
+	KeyPreset        string              `json:"key_preset,omitempty"`
+	Keys             map[string][]string `json:"keys,omitempty"`
 }
 
 func showTimerNotification(issueId, timeValue string) {
@@ -1013,25 +1195,17 @@ type savedTimer struct {
 	SavedAt      time.Time     `json:"saved_at"`
 	LimitedTimer bool          `json:"limited_timer"`
 	TimerLimit   time.Duration `json:"timer_limit"`
+	SessionStart time.Time     `json:"session_start,omitempty"`
+	Adjustment   time.Duration `json:"adjustment,omitempty"`
+	Title        string        `json:"title,omitempty"`
+	Paused       bool          `json:"paused,omitempty"`
+	PausedAt     time.Time     `json:"paused_at,omitempty"`
+	Parked       bool          `json:"parked,omitempty"`
+	Snapshot     bool          `json:"snapshot,omitempty"`
 }
 
-func saveTimer(
-	issueID string,
-	duration time.Duration,
-	startTime time.Time,
-	totalPaused time.Duration,
-	limitedTimer bool,
-	timerLimit time.Duration,
-) {
-	saved := savedTimer{
-		IssueID:      issueID,
-		Duration:     duration,
-		StartTime:    startTime,
-		TotalPaused:  totalPaused,
-		SavedAt:      time.Now(),
-		LimitedTimer: limitedTimer,
-		TimerLimit:   timerLimit,
-	}
+func saveTimer(saved savedTimer) {
+	saved.SavedAt = time.Now()
 
 	b, err := json.MarshalIndent(saved, "", "  ")
 	if err != nil {
@@ -1040,7 +1214,7 @@ func saveTimer(
 	}
 
 	err = os.WriteFile(
-		os.Getenv("HOME")+"/.config/unitrack/saved_timer_"+strings.ReplaceAll(issueID, "/", "_")+".json",
+		os.Getenv("HOME")+"/.config/unitrack/saved_timer_"+strings.ReplaceAll(saved.IssueID, "/", "_")+".json",
 		b,
 		0600,
 	)
@@ -1066,12 +1240,8 @@ func loadSavedTimer(issueID string) *savedTimer {
 
 	expireDays := 5
 
-	b, err = os.ReadFile(os.Getenv("HOME") + "/.config/unitrack/unitrack.json")
-	if err == nil {
-		var cfg apiConfig
-		if json.Unmarshal(b, &cfg) == nil && cfg.TimerExpireDays > 0 {
-			expireDays = cfg.TimerExpireDays
-		}
+	if cfg, _ := currentConfig(); cfg.TimerExpireDays > 0 {
+		expireDays = cfg.TimerExpireDays
 	}
 
 	if time.Since(saved.SavedAt) > time.Duration(expireDays)*24*time.Hour {
@@ -1089,44 +1259,41 @@ func deleteSavedTimer(issueID string) {
 }
 
 func main() {
-	if len(os.Args) > 1 && os.Args[1] == "--version" {
-		fmt.Printf("unitrack %s\n", version)
-		return
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "--version":
+			fmt.Printf("unitrack %s\n", version)
+			return
+		case "report":
+			os.Exit(runReport(os.Args[2:]))
+		case "daemon":
+			os.Exit(runDaemon(os.Args[2:]))
+		case "export":
+			os.Exit(runExport(os.Args[2:]))
+		case "start", "pause", "resume", "stop", "cancel":
+			os.Exit(runTimerCommand(os.Args[1], os.Args[2:]))
+		case "status":
+			os.Exit(runStatus(os.Args[2:]))
+		}
 	}
 
-	theme := "dark"
-	prefix := "UE"
-	b, err := os.ReadFile(os.Getenv("HOME") + "/.config/unitrack/unitrack.json")
-	if err == nil {
-		var cfg apiConfig
-		if json.Unmarshal(b, &cfg) == nil {
-			if cfg.Theme != "" {
-				theme = cfg.Theme
-			}
-			if cfg.Prefix != "" {
-				prefix = cfg.Prefix
-			}
-		}
+	fs := flag.NewFlagSet("unitrack", flag.ContinueOnError)
+	startBranch := fs.Bool("start-branch", false, "start timing the issue detected from the current git branch")
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		os.Exit(2)
 	}
-	initializeTheme(theme)
+
+	cfg, cfgErr := currentConfig()
 
 	input := textinput.New()
-	input.Placeholder = prefix + "-1234"
-	input.CharLimit = 10
+	input.CharLimit = issueInputLimit
 	input.Width = 8
 	input.Focus()
 
 	helpModel := help.New()
-	helpModel.Styles.ShortKey = lipgloss.NewStyle().Foreground(colorLightGray)
-	helpModel.Styles.ShortDesc = lipgloss.NewStyle().Foreground(colorGray)
-	helpModel.Styles.ShortSeparator = lipgloss.NewStyle().Foreground(colorGray)
-	helpModel.Styles.FullKey = lipgloss.NewStyle().Foreground(colorLightGray)
-	helpModel.Styles.FullDesc = lipgloss.NewStyle().Foreground(colorGray)
-	helpModel.Styles.FullSeparator = lipgloss.NewStyle().Foreground(colorGray)
 
 	spinnerModel := spinner.New()
 	spinnerModel.Spinner = spinner.Dot
-	spinnerModel.Style = lipgloss.NewStyle().Foreground(colorOrange)
 
 	limitInput := textinput.New()
 	limitInput.Placeholder = "15"
@@ -1136,21 +1303,72 @@ func main() {
 	progressBar := progress.New(progress.WithDefaultGradient())
 	progressBar.Width = 40
 
+	switchInput := textinput.New()
+	switchInput.CharLimit = issueInputLimit
+	switchInput.Width = 8
+
+	searchInput := textinput.New()
+	searchInput.Placeholder = "login bug"
+	searchInput.CharLimit = 100
+	searchInput.Width = 40
+
 	m := model{
 		input:       input,
-		message:     "Enter issue ID and hit 'enter' to start timer or 'l' to set up limited timer.",
 		help:        helpModel,
 		keys:        keys,
 		spinner:     spinnerModel,
 		limitInput:  limitInput,
 		progressBar: progressBar,
-		titleCache:  make(map[string]string),
+		titles:      newTitleLookup(),
+		switchInput: switchInput,
+		searchInput: searchInput,
 	}
 
+	m.applyConfig(cfg, cfgErr)
+	m.message = fmt.Sprintf(
+		"Enter issue ID and hit '%s' to start timer or '%s' to set up limited timer.",
+		m.keys.Start.Help().Key,
+		m.keys.LimitedTimer.Help().Key,
+	)
 	m.history = loadHistory()
+	if issueID, branch, ok := detectBranchIssue(); ok {
+		m.input.SetValue(issueID)
+		m.lastInputValue = issueID
+		m.branchIssue = issueID
+		m.startBranch = *startBranch
+		m.message = fmt.Sprintf(
+			"Detected %s from git branch %s. Press '%s' to start the timer.",
+			issueID,
+			branch,
+			m.keys.Start.Help().Key,
+		)
+	} else if *startBranch {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: no issue ID found in the current git branch\n")
+		os.Exit(1)
+	}
 
-	if _, err = tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
+	if daemonAvailable() {
+		m.daemon = true
+		m.message = "Connected to unitrack daemon. " + m.message
+	} else {
+		unlock, ok := lockTimers()
+		if ok {
+			defer unlock()
+		} else {
+			m.message = "Another unitrack is using the saved timers. " + m.message
+		}
+		for _, t := range loadSavedTimers() {
+			m.parked = append(m.parked, t.settle(false))
+		}
+	}
+
+	final, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+
+	if fm, ok := final.(model); ok && !fm.daemon {
+		fm.pauseOnQuit()
+	}
 }
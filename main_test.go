@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func testHome(t *testing.T, config string) string {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(linearEndpointEnv, "")
+	t.Chdir(home)
+
+	dir := filepath.Join(home, ".config", "unitrack")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if config != "" {
+		if err := os.WriteFile(filepath.Join(dir, "unitrack.json"), []byte(config), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resetConfig := func() {
+		configState.Lock()
+		configState.loaded = false
+		configState.Unlock()
+	}
+	resetConfig()
+	t.Cleanup(resetConfig)
+
+	return home
+}
+
+func TestConfirmCancelDeny(t *testing.T) {
+	tests := []struct {
+		name   string
+		daemon bool
+		want   int
+	}{
+		{name: "local", want: 2},
+		{name: "daemon", daemon: true, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := model{
+				keys:        keys,
+				spinner:     spinner.New(),
+				titles:      newTitleLookup(),
+				screen:      screenConfirmCancel,
+				timerActive: true,
+				daemon:      tt.daemon,
+			}
+
+			next, cmd := m.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+			if screen := next.(model).screen; screen != screenMain {
+				t.Errorf("screen = %v, want screenMain", screen)
+			}
+
+			got := 0
+			if cmd != nil {
+				got = 1
+				if batch, ok := cmd().(tea.BatchMsg); ok {
+					got = len(batch)
+				}
+			}
+			if got != tt.want {
+				t.Errorf("deny returned %d commands, want %d", got, tt.want)
+			}
+		})
+	}
+}
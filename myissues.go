@@ -0,0 +1,322 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	myIssuesLimit   = 50
+	groupByState    = "state"
+	groupByCycle    = "cycle"
+	noCycleGroupKey = "No cycle"
+)
+
+type myIssuesKeyMap struct {
+	Up      key.Binding
+	Down    key.Binding
+	Select  key.Binding
+	Group   key.Binding
+	Refresh key.Binding
+	Back    key.Binding
+}
+
+func (k myIssuesKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Select, k.Group, k.Refresh, k.Back}
+}
+
+func (k myIssuesKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Select},
+		{k.Group, k.Refresh, k.Back},
+	}
+}
+
+var myIssuesKeys = defaultMyIssuesKeyMap()
+
+func defaultMyIssuesKeyMap() myIssuesKeyMap {
+	return myIssuesKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		Select: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "start timer"),
+		),
+		Group: key.NewBinding(
+			key.WithKeys("g"),
+			key.WithHelp("g", "group by state/cycle"),
+		),
+		Refresh: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "refresh"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back"),
+		),
+	}
+}
+
+type myIssuesMsg struct {
+	issues []linearIssue
+	err    error
+}
+
+type issueGroup struct {
+	name   string
+	issues []linearIssue
+}
+
+func fetchMyIssuesCmd(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		client, err := newLinearAPI()
+		if err != nil {
+			return myIssuesMsg{err: err}
+		}
+
+		issues, err := client.assignedIssues(ctx, myIssuesLimit)
+		if errors.Is(err, context.Canceled) {
+			return myIssuesMsg{err: err}
+		}
+		if err != nil {
+			logError(fmt.Sprintf("Failed to load assigned issues: %v", err))
+		} else {
+			storeLinearIssues(issues)
+		}
+
+		return myIssuesMsg{issues: issues, err: err}
+	}
+}
+
+func (m model) openMyIssues() (model, tea.Cmd) {
+	m.screen = screenMyIssues
+	m.myIssuesIndex = 0
+	m.myIssuesError = ""
+	if m.myIssuesGroup == "" {
+		m.myIssuesGroup = groupByState
+	}
+	cmd := m.fetchMyIssues()
+
+	return m, cmd
+}
+
+func (m *model) fetchMyIssues() tea.Cmd {
+	m.cancelMyIssues()
+	ctx, cancel := context.WithTimeout(context.Background(), titleLookupTimeout)
+	m.myIssuesCancel = cancel
+	m.myIssuesLoading = true
+
+	return fetchMyIssuesCmd(ctx)
+}
+
+func (m *model) cancelMyIssues() {
+	if m.myIssuesCancel != nil {
+		m.myIssuesCancel()
+		m.myIssuesCancel = nil
+	}
+}
+
+func formatEstimate(estimate *float64) string {
+	if estimate == nil {
+		return ""
+	}
+
+	return "est. " + strconv.FormatFloat(*estimate, 'f', -1, 64)
+}
+
+func groupIssues(issues []linearIssue, by string) []issueGroup {
+	sorted := make([]linearIssue, len(issues))
+	copy(sorted, issues)
+
+	stateRank := func(issue linearIssue) int {
+		if issue.State.Type == "started" {
+			return 0
+		}
+		return 1
+	}
+	cycleRank := func(issue linearIssue) int {
+		if issue.Cycle == nil {
+			return int(^uint(0) >> 1)
+		}
+		return issue.Cycle.Number
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if by == groupByCycle {
+			return cycleRank(a) < cycleRank(b)
+		}
+		if stateRank(a) != stateRank(b) {
+			return stateRank(a) < stateRank(b)
+		}
+		return a.State.Position < b.State.Position
+	})
+
+	var groups []issueGroup
+	for _, issue := range sorted {
+		name := issue.State.Name
+		if by == groupByCycle {
+			name = noCycleGroupKey
+			if issue.Cycle != nil {
+				name = fmt.Sprintf("Cycle %d", issue.Cycle.Number)
+				if issue.Cycle.Name != "" {
+					name += ": " + issue.Cycle.Name
+				}
+			}
+		}
+
+		if len(groups) == 0 || groups[len(groups)-1].name != name {
+			groups = append(groups, issueGroup{name: name})
+		}
+		groups[len(groups)-1].issues = append(groups[len(groups)-1].issues, issue)
+	}
+
+	return groups
+}
+
+func (m model) orderedMyIssues() []linearIssue {
+	var out []linearIssue
+	for _, g := range groupIssues(m.myIssues, m.myIssuesGroup) {
+		out = append(out, g.issues...)
+	}
+
+	return out
+}
+
+func (m model) updateMyIssues(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch message := msg.(type) {
+	case timerMsg:
+		if m.timerActive && !m.timerPaused {
+			return m.tick()
+		}
+
+		return m, nil
+
+	case myIssuesMsg:
+		if errors.Is(message.err, context.Canceled) {
+			return m, nil
+		}
+
+		m.cancelMyIssues()
+		m.myIssuesLoading = false
+		m.myIssuesError = ""
+		if message.err != nil {
+			m.myIssuesError = fmt.Sprintf("Could not load your issues: %v", message.err)
+			return m, nil
+		}
+
+		m.myIssues = message.issues
+		if m.myIssuesIndex >= len(m.myIssues) {
+			m.myIssuesIndex = 0
+		}
+
+		return m, nil
+
+	case tea.KeyMsg:
+		issues := m.orderedMyIssues()
+
+		switch {
+		case key.Matches(message, myIssuesKeys.Back):
+			m.screen = screenMain
+			m.cancelMyIssues()
+
+			return m, nil
+
+		case key.Matches(message, myIssuesKeys.Up):
+			if m.myIssuesIndex > 0 {
+				m.myIssuesIndex--
+			}
+
+		case key.Matches(message, myIssuesKeys.Down):
+			if m.myIssuesIndex < len(issues)-1 {
+				m.myIssuesIndex++
+			}
+
+		case key.Matches(message, myIssuesKeys.Group):
+			if m.myIssuesGroup == groupByCycle {
+				m.myIssuesGroup = groupByState
+			} else {
+				m.myIssuesGroup = groupByCycle
+			}
+			m.myIssuesIndex = 0
+
+		case key.Matches(message, myIssuesKeys.Refresh):
+			cmd := m.fetchMyIssues()
+
+			return m, cmd
+
+		case key.Matches(message, myIssuesKeys.Select):
+			if len(issues) == 0 {
+				return m, nil
+			}
+
+			return m.selectIssue(issues[m.myIssuesIndex])
+		}
+	}
+
+	return m, nil
+}
+
+func (m model) myIssuesView() string {
+	titleLine := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		logoStyle.Render("⏱ unitrack"),
+		headerBar.Render("My Issues"),
+	)
+
+	var rows []string
+	switch {
+	case m.myIssuesError != "":
+		rows = append(rows, listItemStyle.Render(m.myIssuesError))
+	case m.myIssuesLoading && len(m.myIssues) == 0:
+		rows = append(rows, listItemStyle.Render("Loading issues assigned to you..."))
+	case len(m.myIssues) == 0:
+		rows = append(rows, listItemStyle.Render("No started or unstarted issues are assigned to you."))
+	}
+
+	i := 0
+	for _, g := range groupIssues(m.myIssues, m.myIssuesGroup) {
+		rows = append(rows, inputLabel.Render(fmt.Sprintf("%s (%d)", g.name, len(g.issues))))
+
+		for _, issue := range g.issues {
+			cursor := "  "
+			style := listItemStyle
+			if i == m.myIssuesIndex {
+				cursor = "> "
+				style = listSelectedStyle
+			}
+			i++
+
+			title := truncateTitle(issue.Title, listTitleWidth)
+
+			detail := formatEstimate(issue.Estimate)
+			if m.myIssuesGroup == groupByCycle {
+				detail = strings.TrimSpace(issue.State.Name + "  " + detail)
+			}
+
+			line := fmt.Sprintf("%s%-10s %-60s %s", cursor, issue.Identifier, title, detail)
+			rows = append(rows, style.Render(strings.TrimRight(line, " ")))
+		}
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		titleLine,
+		lipgloss.JoinVertical(lipgloss.Top, rows...),
+		helpStyle.Render(m.help.View(myIssuesKeys)),
+	)
+}
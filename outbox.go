@@ -0,0 +1,354 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	outboxRetryInterval = 30 * time.Second
+	outboxMaxBackoff    = 30 * time.Minute
+)
+
+type outboxEntry struct {
+	ID          string        `json:"id"`
+	IssueID     string        `json:"issue_id"`
+	Body        string        `json:"body"`
+	Session     sessionRecord `json:"session"`
+	Attempts    int           `json:"attempts"`
+	LastError   string        `json:"last_error,omitempty"`
+	NextAttempt time.Time     `json:"next_attempt"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type outboxStatusMsg struct {
+	pending int
+	failed  int
+	text    string
+}
+
+type outboxTickMsg struct{}
+
+var (
+	outboxMu      sync.Mutex
+	outboxFlushMu sync.Mutex
+)
+
+func outboxPath() string {
+	return os.Getenv("HOME") + "/.config/unitrack/outbox.json"
+}
+
+func outboxLockPath() string {
+	return outboxPath() + ".lock"
+}
+
+func outboxDataLockPath() string {
+	return outboxPath() + ".data.lock"
+}
+
+func lockOutboxData() func() {
+	unlock, _, err := lockFile(outboxDataLockPath(), true)
+	if err != nil {
+		logError(fmt.Sprintf("Failed to lock outbox: %v", err))
+		return func() {}
+	}
+
+	return unlock
+}
+
+func lockOutboxDelivery(wait bool) (func(), bool) {
+	unlock, ok, err := lockFile(outboxLockPath(), wait)
+	if err != nil {
+		logError(fmt.Sprintf("Failed to lock outbox for delivery: %v", err))
+		return nil, false
+	}
+
+	return unlock, ok
+}
+
+func readOutbox() []outboxEntry {
+	b, err := os.ReadFile(outboxPath())
+	if err != nil {
+		return nil
+	}
+
+	var entries []outboxEntry
+	if err = json.Unmarshal(b, &entries); err != nil {
+		logError(fmt.Sprintf("Failed to unmarshal outbox: %v", err))
+		return nil
+	}
+
+	return entries
+}
+
+func writeOutbox(entries []outboxEntry) {
+	_ = os.MkdirAll(os.Getenv("HOME")+"/.config/unitrack", 0700)
+
+	if len(entries) == 0 {
+		_ = os.Remove(outboxPath())
+		return
+	}
+
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		logError(fmt.Sprintf("Failed to marshal outbox: %v", err))
+		return
+	}
+
+	tmp := outboxPath() + ".tmp"
+	if err = os.WriteFile(tmp, b, 0600); err != nil {
+		logError(fmt.Sprintf("Failed to write outbox: %v", err))
+		return
+	}
+
+	if err = os.Rename(tmp, outboxPath()); err != nil {
+		logError(fmt.Sprintf("Failed to replace outbox: %v", err))
+	}
+}
+
+func updateOutbox(fn func([]outboxEntry) []outboxEntry) {
+	outboxMu.Lock()
+	defer outboxMu.Unlock()
+	defer lockOutboxData()()
+
+	writeOutbox(fn(readOutbox()))
+}
+
+func outboxCounts() (pending, failed int) {
+	outboxMu.Lock()
+	defer outboxMu.Unlock()
+	defer lockOutboxData()()
+
+	for _, e := range readOutbox() {
+		if e.Attempts > 0 {
+			failed++
+		} else {
+			pending++
+		}
+	}
+
+	return pending, failed
+}
+
+func enqueueOutbox(entry outboxEntry) {
+	updateOutbox(func(entries []outboxEntry) []outboxEntry {
+		return append(entries, entry)
+	})
+}
+
+func outboxBackoff(attempts int) time.Duration {
+	backoff := outboxRetryInterval
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= outboxMaxBackoff {
+			return outboxMaxBackoff
+		}
+	}
+
+	return backoff
+}
+
+var errOutboxEntryGone = errors.New("submission is no longer in the outbox")
+
+type submitResultMsg struct {
+	id        string
+	issueID   string
+	rounded   string
+	commentID string
+	err       error
+	pending   int
+	failed    int
+}
+
+func findOutboxEntry(id string) (outboxEntry, bool) {
+	outboxMu.Lock()
+	defer outboxMu.Unlock()
+	defer lockOutboxData()()
+
+	for _, e := range readOutbox() {
+		if e.ID == id {
+			return e, true
+		}
+	}
+
+	return outboxEntry{}, false
+}
+
+func deliverOutboxEntry(entry outboxEntry) (string, error) {
+	commentID, err := postLinearComment(entry.IssueID, entry.Body)
+
+	updateOutbox(func(current []outboxEntry) []outboxEntry {
+		var out []outboxEntry
+		for _, e := range current {
+			if e.ID != entry.ID {
+				out = append(out, e)
+				continue
+			}
+
+			if err == nil {
+				continue
+			}
+
+			e.Attempts++
+			e.LastError = err.Error()
+			e.NextAttempt = time.Now().Add(outboxBackoff(e.Attempts))
+			out = append(out, e)
+		}
+
+		return out
+	})
+
+	session := entry.Session
+	if err == nil {
+		session.Status = sessionSubmitted
+		session.Error = ""
+		session.CommentID = commentID
+		appendSession(session)
+	} else if entry.Attempts == 0 {
+		session.Status = sessionFailed
+		session.Error = err.Error()
+		appendSession(session)
+	}
+
+	return commentID, err
+}
+
+func flushOutbox() (attempted, delivered int) {
+	if !outboxFlushMu.TryLock() {
+		return 0, 0
+	}
+	defer outboxFlushMu.Unlock()
+
+	unlock, ok := lockOutboxDelivery(false)
+	if !ok {
+		return 0, 0
+	}
+	defer unlock()
+
+	outboxMu.Lock()
+	unlockData := lockOutboxData()
+	entries := readOutbox()
+	unlockData()
+	outboxMu.Unlock()
+
+	now := time.Now()
+	for _, entry := range entries {
+		if entry.NextAttempt.After(now) {
+			continue
+		}
+
+		attempted++
+		if _, err := deliverOutboxEntry(entry); err == nil {
+			delivered++
+		}
+	}
+
+	return attempted, delivered
+}
+
+func retryAllText(attempted, delivered int) string {
+	if attempted == 0 {
+		return "Nothing to retry."
+	}
+
+	return fmt.Sprintf("Retried queued submissions: %d posted, %d still queued.", delivered, attempted-delivered)
+}
+
+func flushOutboxCmd() tea.Cmd {
+	return func() tea.Msg {
+		flushOutbox()
+		pending, failed := outboxCounts()
+
+		return outboxStatusMsg{pending: pending, failed: failed}
+	}
+}
+
+func outboxStatusCmd() tea.Cmd {
+	return func() tea.Msg {
+		pending, failed := outboxCounts()
+
+		return outboxStatusMsg{pending: pending, failed: failed}
+	}
+}
+
+func tickOutbox() tea.Cmd {
+	return tea.Tick(outboxRetryInterval, func(time.Time) tea.Msg {
+		return outboxTickMsg{}
+	})
+}
+
+func deliverOutboxCmd(id string) tea.Cmd {
+	return func() tea.Msg {
+		outboxFlushMu.Lock()
+		var result submitResultMsg
+		if unlock, locked := lockOutboxDelivery(true); locked {
+			entry, ok := findOutboxEntry(id)
+			if ok {
+				result.issueID = entry.IssueID
+				result.rounded = entry.Session.Rounded
+				result.commentID, result.err = deliverOutboxEntry(entry)
+			} else {
+				result.err = errOutboxEntryGone
+			}
+			unlock()
+		} else {
+			result.err = errors.New("could not lock the outbox")
+		}
+		outboxFlushMu.Unlock()
+
+		result.id = id
+		result.pending, result.failed = outboxCounts()
+
+		return result
+	}
+}
+
+func submitSession(rec sessionRecord) tea.Cmd {
+	rec.Status = sessionQueued
+	appendSession(rec)
+
+	now := time.Now()
+	enqueueOutbox(outboxEntry{
+		ID:          rec.ID,
+		IssueID:     rec.IssueID,
+		Body:        commentBody(rec),
+		Session:     rec,
+		NextAttempt: now.Add(outboxRetryInterval),
+		CreatedAt:   now,
+	})
+
+	return deliverOutboxCmd(rec.ID)
+}
+
+func retryOutbox(id string) {
+	updateOutbox(func(entries []outboxEntry) []outboxEntry {
+		for i := range entries {
+			if id == "" || entries[i].ID == id {
+				entries[i].NextAttempt = time.Time{}
+			}
+		}
+
+		return entries
+	})
+}
+
+func retryOutboxCmd(id string) tea.Cmd {
+	retryOutbox(id)
+
+	if id == "" {
+		return func() tea.Msg {
+			text := retryAllText(flushOutbox())
+			pending, failed := outboxCounts()
+
+			return outboxStatusMsg{pending: pending, failed: failed, text: text}
+		}
+	}
+
+	return deliverOutboxCmd(id)
+}
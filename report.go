@@ -0,0 +1,219 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+type reportRow struct {
+	key      string
+	label    string
+	sessions int
+	raw      time.Duration
+	rounded  time.Duration
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, mo, d := t.Date()
+	return time.Date(y, mo, d, 0, 0, 0, 0, t.Location())
+}
+
+func startOfWeek(t time.Time) time.Time {
+	day := startOfDay(t)
+	offset := (int(day.Weekday()) + 6) % 7
+
+	return day.AddDate(0, 0, -offset)
+}
+
+func parseDateRange(from, to string, defaultFrom time.Time) (time.Time, time.Time, error) {
+	start := defaultFrom
+	end := time.Time{}
+
+	if from != "" {
+		t, err := time.ParseInLocation(dateLayout, from, time.Local)
+		if err != nil {
+			return start, end, fmt.Errorf("invalid --from date %q, expected YYYY-MM-DD", from)
+		}
+		start = t
+	}
+
+	if to != "" {
+		t, err := time.ParseInLocation(dateLayout, to, time.Local)
+		if err != nil {
+			return start, end, fmt.Errorf("invalid --to date %q, expected YYYY-MM-DD", to)
+		}
+		end = t.AddDate(0, 0, 1)
+	}
+
+	if !end.IsZero() && !start.IsZero() && !end.After(start) {
+		return start, end, fmt.Errorf("--to must not be before --from")
+	}
+
+	return start, end, nil
+}
+
+func filterSessions(sessions []sessionRecord, start, end time.Time, issue string) []sessionRecord {
+	var out []sessionRecord
+	for _, s := range sessions {
+		if !start.IsZero() && s.Start.Before(start) {
+			continue
+		}
+		if !end.IsZero() && !s.Start.Before(end) {
+			continue
+		}
+		if issue != "" && !strings.EqualFold(s.IssueID, issue) {
+			continue
+		}
+		out = append(out, s)
+	}
+
+	return out
+}
+
+func parseRounded(value string) (time.Duration, error) {
+	h, m, ok := strings.Cut(value, ":")
+	if !ok {
+		return 0, fmt.Errorf("invalid rounded value %q", value)
+	}
+
+	hours, err := strconv.Atoi(h)
+	if err != nil {
+		return 0, fmt.Errorf("invalid rounded value %q", value)
+	}
+
+	minutes, err := strconv.Atoi(m)
+	if err != nil {
+		return 0, fmt.Errorf("invalid rounded value %q", value)
+	}
+
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute, nil
+}
+
+func sessionRounded(s sessionRecord, policy roundingPolicy) time.Duration {
+	if d, err := parseRounded(s.Rounded); err == nil {
+		return d
+	}
+
+	return policy.round(s.Duration)
+}
+
+func aggregateSessions(sessions []sessionRecord, by string, policy roundingPolicy) []reportRow {
+	rows := make(map[string]*reportRow)
+	for _, s := range sessions {
+		local := s.Start.Local()
+
+		var key, label string
+		switch by {
+		case "week":
+			week := startOfWeek(local)
+			y, w := week.ISOWeek()
+			key = week.Format(dateLayout)
+			label = fmt.Sprintf("%d-W%02d (%s)", y, w, week.Format(dateLayout))
+		case "issue":
+			key = s.IssueID
+			label = s.IssueID
+			if s.Title != "" {
+				label += "  " + s.Title
+			}
+		default:
+			key = local.Format(dateLayout)
+			label = local.Format("2006-01-02 Mon")
+		}
+
+		row, ok := rows[key]
+		if !ok {
+			row = &reportRow{key: key, label: label}
+			rows[key] = row
+		}
+		if by == "issue" && s.Title != "" {
+			row.label = s.IssueID + "  " + s.Title
+		}
+
+		row.sessions++
+		row.raw += s.Duration
+		row.rounded += sessionRounded(s, policy)
+	}
+
+	out := make([]reportRow, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, *row)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].key < out[j].key
+	})
+
+	return out
+}
+
+func printReport(w io.Writer, rows []reportRow, by string) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	var header string
+	switch by {
+	case "week":
+		header = "WEEK"
+	case "issue":
+		header = "ISSUE"
+	default:
+		header = "DAY"
+	}
+	_, _ = fmt.Fprintf(tw, "%s\tSESSIONS\tRAW\tROUNDED\n", header)
+
+	var total reportRow
+	for _, row := range rows {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n", row.label, row.sessions, fmtDuration(row.raw), fmtHoursMinutes(row.rounded))
+		total.sessions += row.sessions
+		total.raw += row.raw
+		total.rounded += row.rounded
+	}
+
+	_, _ = fmt.Fprintf(tw, "TOTAL\t%d\t%s\t%s\n", total.sessions, fmtDuration(total.raw), fmtHoursMinutes(total.rounded))
+	_ = tw.Flush()
+}
+
+func runReport(args []string) int {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	by := fs.String("by", "day", "group sessions by day, week or issue")
+	from := fs.String("from", "", "first day to include (YYYY-MM-DD, default: start of this week)")
+	to := fs.String("to", "", "last day to include (YYYY-MM-DD, default: today)")
+	issue := fs.String("issue", "", "only include sessions for this issue ID")
+	all := fs.Bool("all", false, "include all recorded sessions regardless of date")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if *by != "day" && *by != "week" && *by != "issue" {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: --by must be day, week or issue, got %q\n", *by)
+		return 2
+	}
+
+	defaultFrom := startOfWeek(time.Now())
+	if *all {
+		defaultFrom = time.Time{}
+	}
+
+	start, end, err := parseDateRange(*from, *to, defaultFrom)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	sessions := filterSessions(loadSessions(), start, end, *issue)
+	if len(sessions) == 0 {
+		fmt.Println("No tracked sessions in the selected range.")
+		return 0
+	}
+
+	printReport(os.Stdout, aggregateSessions(sessions, *by, loadRoundingPolicy()), *by)
+
+	return 0
+}
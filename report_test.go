@@ -0,0 +1,94 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateRange(t *testing.T) {
+	defaultFrom := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name      string
+		from, to  string
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   bool
+	}{
+		{name: "defaults", wantStart: defaultFrom},
+		{name: "from", from: "2026-02-10", wantStart: day(2026, 2, 10)},
+		{name: "to includes the day", to: "2026-03-05", wantStart: defaultFrom, wantEnd: day(2026, 3, 6)},
+		{name: "single day", from: "2026-03-05", to: "2026-03-05", wantStart: day(2026, 3, 5), wantEnd: day(2026, 3, 6)},
+		{name: "invalid from", from: "05.03.2026", wantErr: true},
+		{name: "invalid to", to: "2026-3-5x", wantErr: true},
+		{name: "to before from", from: "2026-03-05", to: "2026-03-04", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := parseDateRange(tt.from, tt.to, defaultFrom)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDateRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Errorf("parseDateRange() = %s, %s, want %s, %s", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestAggregateSessions(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	sessions := []sessionRecord{
+		{IssueID: "UE-1", Start: monday, Duration: 20 * time.Minute, Rounded: "0:30"},
+		{IssueID: "UE-2", Title: "Fix login", Start: monday.Add(2 * time.Hour), Duration: 10 * time.Minute},
+		{IssueID: "UE-1", Title: "Search", Start: monday.AddDate(0, 0, 1), Duration: 50 * time.Minute, Rounded: "1:00"},
+		{IssueID: "UE-1", Start: monday.AddDate(0, 0, 7), Duration: 5 * time.Minute, Rounded: "0:15"},
+	}
+
+	type row struct {
+		label    string
+		sessions int
+		raw      time.Duration
+		rounded  time.Duration
+	}
+	tests := []struct {
+		by   string
+		want []row
+	}{
+		{by: "day", want: []row{
+			{"2026-03-02 Mon", 2, 30 * time.Minute, 45 * time.Minute},
+			{"2026-03-03 Tue", 1, 50 * time.Minute, time.Hour},
+			{"2026-03-09 Mon", 1, 5 * time.Minute, 15 * time.Minute},
+		}},
+		{by: "week", want: []row{
+			{"2026-W10 (2026-03-02)", 3, 80 * time.Minute, 105 * time.Minute},
+			{"2026-W11 (2026-03-09)", 1, 5 * time.Minute, 15 * time.Minute},
+		}},
+		{by: "issue", want: []row{
+			{"UE-1  Search", 3, 75 * time.Minute, 105 * time.Minute},
+			{"UE-2  Fix login", 1, 10 * time.Minute, 15 * time.Minute},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			rows := aggregateSessions(sessions, tt.by, defaultRoundingPolicy)
+			if len(rows) != len(tt.want) {
+				t.Fatalf("aggregateSessions() returned %d rows, want %d: %+v", len(rows), len(tt.want), rows)
+			}
+			for i, want := range tt.want {
+				got := row{rows[i].label, rows[i].sessions, rows[i].raw, rows[i].rounded}
+				if got != want {
+					t.Errorf("row %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	roundCeil    = "ceil"
+	roundFloor   = "floor"
+	roundNearest = "nearest"
+	roundNone    = "none"
+)
+
+type roundingConfig struct {
+	Increment int    `json:"increment,omitempty"`
+	Mode      string `json:"mode,omitempty"`
+	Minimum   int    `json:"minimum,omitempty"`
+}
+
+type roundingPolicy struct {
+	increment time.Duration
+	mode      string
+	minimum   time.Duration
+}
+
+var defaultRoundingPolicy = roundingPolicy{
+	increment: 15 * time.Minute,
+	mode:      roundCeil,
+}
+
+func newRoundingPolicy(cfg *roundingConfig) (roundingPolicy, error) {
+	policy := defaultRoundingPolicy
+	if cfg == nil {
+		return policy, nil
+	}
+
+	if cfg.Increment < 0 {
+		return policy, fmt.Errorf("rounding.increment must not be negative, got %d", cfg.Increment)
+	}
+	if cfg.Increment > 0 {
+		policy.increment = time.Duration(cfg.Increment) * time.Minute
+	}
+
+	switch cfg.Mode {
+	case "":
+	case roundCeil, roundFloor, roundNearest, roundNone:
+		policy.mode = cfg.Mode
+	default:
+		return policy, fmt.Errorf("rounding.mode must be ceil, floor, nearest or none, got %q", cfg.Mode)
+	}
+
+	if cfg.Minimum < 0 {
+		return policy, fmt.Errorf("rounding.minimum must not be negative, got %d", cfg.Minimum)
+	}
+	policy.minimum = time.Duration(cfg.Minimum) * time.Minute
+
+	return policy, nil
+}
+
+func loadRoundingPolicy() roundingPolicy {
+	cfg, _ := currentConfig()
+
+	policy, err := newRoundingPolicy(cfg.Rounding)
+	if err != nil {
+		logError(fmt.Sprintf("Invalid rounding config, using quarter-hour ceiling: %v", err))
+		return defaultRoundingPolicy
+	}
+
+	return policy
+}
+
+func (p roundingPolicy) round(d time.Duration) time.Duration {
+	d = d.Truncate(time.Second)
+	if d <= 0 {
+		return 0
+	}
+
+	var rounded time.Duration
+	switch p.mode {
+	case roundNone:
+		rounded = d.Truncate(time.Minute)
+	case roundFloor:
+		rounded = d.Truncate(p.increment)
+	case roundNearest:
+		rounded = d.Round(p.increment)
+	default:
+		rounded = d.Truncate(p.increment)
+		if rounded < d {
+			rounded += p.increment
+		}
+	}
+
+	if rounded < p.minimum {
+		rounded = p.minimum
+	}
+
+	return rounded
+}
+
+func (p roundingPolicy) checkPostable(issueID string, d time.Duration) error {
+	if p.round(d) > 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%s on %s rounds to 0:00 (%s), so there is nothing to post", fmtDuration(d), issueID, p)
+}
+
+func (p roundingPolicy) format(d time.Duration) string {
+	return fmtHoursMinutes(p.round(d))
+}
+
+func (p roundingPolicy) String() string {
+	if p.mode == roundNone {
+		return "no rounding"
+	}
+
+	s := fmt.Sprintf("%s to %d min", p.mode, int(p.increment.Minutes()))
+	if p.minimum > 0 {
+		s += fmt.Sprintf(", min %d min", int(p.minimum.Minutes()))
+	}
+
+	return s
+}
+
+func fmtHoursMinutes(d time.Duration) string {
+	t := int(d.Minutes())
+
+	return fmt.Sprintf("%d:%02d", t/60, t%60)
+}
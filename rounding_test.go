@@ -0,0 +1,90 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRoundingPolicyRound(t *testing.T) {
+	ceil15 := defaultRoundingPolicy
+	floor15 := roundingPolicy{increment: 15 * time.Minute, mode: roundFloor}
+	nearest15 := roundingPolicy{increment: 15 * time.Minute, mode: roundNearest}
+	none := roundingPolicy{increment: 15 * time.Minute, mode: roundNone}
+	ceil6 := roundingPolicy{increment: 6 * time.Minute, mode: roundCeil}
+	floorMin30 := roundingPolicy{increment: 15 * time.Minute, mode: roundFloor, minimum: 30 * time.Minute}
+
+	tests := []struct {
+		name   string
+		policy roundingPolicy
+		in     time.Duration
+		want   time.Duration
+	}{
+		{"ceil zero", ceil15, 0, 0},
+		{"ceil sub-second", ceil15, 500 * time.Millisecond, 0},
+		{"ceil one second", ceil15, time.Second, 15 * time.Minute},
+		{"ceil exact", ceil15, 15 * time.Minute, 15 * time.Minute},
+		{"ceil just over", ceil15, 15*time.Minute + time.Second, 30 * time.Minute},
+		{"ceil 6 min", ceil6, 7 * time.Minute, 12 * time.Minute},
+		{"floor below step", floor15, 14 * time.Minute, 0},
+		{"floor", floor15, 29 * time.Minute, 15 * time.Minute},
+		{"nearest down", nearest15, 7 * time.Minute, 0},
+		{"nearest up", nearest15, 8 * time.Minute, 15 * time.Minute},
+		{"nearest half", nearest15, 37*time.Minute + 30*time.Second, 45 * time.Minute},
+		{"none under a minute", none, 59 * time.Second, 0},
+		{"none", none, 90 * time.Second, time.Minute},
+		{"minimum", floorMin30, 10 * time.Minute, 30 * time.Minute},
+		{"minimum above", floorMin30, 50 * time.Minute, 45 * time.Minute},
+		{"minimum zero", floorMin30, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.round(tt.in); got != tt.want {
+				t.Errorf("round(%s) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundingPolicyCheckPostable(t *testing.T) {
+	nearest15 := roundingPolicy{increment: 15 * time.Minute, mode: roundNearest}
+	if err := nearest15.checkPostable("UE-1", 5*time.Minute); err == nil {
+		t.Error("checkPostable(5m) = nil, want an error for 0:00")
+	}
+	if err := nearest15.checkPostable("UE-1", 10*time.Minute); err != nil {
+		t.Errorf("checkPostable(10m) = %v, want nil", err)
+	}
+
+	nearest15.minimum = 15 * time.Minute
+	if err := nearest15.checkPostable("UE-1", 5*time.Minute); err != nil {
+		t.Errorf("checkPostable(5m) with minimum = %v, want nil", err)
+	}
+}
+
+func TestNewRoundingPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *roundingConfig
+		want    roundingPolicy
+		wantErr bool
+	}{
+		{name: "nil", want: defaultRoundingPolicy},
+		{name: "mode only", cfg: &roundingConfig{Mode: roundFloor}, want: roundingPolicy{increment: 15 * time.Minute, mode: roundFloor}},
+		{name: "all", cfg: &roundingConfig{Increment: 6, Mode: roundNearest, Minimum: 30}, want: roundingPolicy{increment: 6 * time.Minute, mode: roundNearest, minimum: 30 * time.Minute}},
+		{name: "unknown mode", cfg: &roundingConfig{Mode: "up"}, wantErr: true},
+		{name: "negative increment", cfg: &roundingConfig{Increment: -1}, wantErr: true},
+		{name: "negative minimum", cfg: &roundingConfig{Minimum: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newRoundingPolicy(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newRoundingPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("newRoundingPolicy() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
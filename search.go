@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	searchDebounce = 300 * time.Millisecond
+	searchLimit    = 20
+)
+
+type searchKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Select key.Binding
+	Back   key.Binding
+}
+
+func (k searchKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Select, k.Back}
+}
+
+func (k searchKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down, k.Select, k.Back}}
+}
+
+var searchKeys = defaultSearchKeyMap()
+
+func defaultSearchKeyMap() searchKeyMap {
+	return searchKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "ctrl+p"),
+			key.WithHelp("↑", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "ctrl+n"),
+			key.WithHelp("↓", "down"),
+		),
+		Select: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "start timer"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc", "ctrl+c"),
+			key.WithHelp("esc", "back"),
+		),
+	}
+}
+
+type searchDebounceMsg struct {
+	query string
+}
+
+type searchResultMsg struct {
+	query  string
+	issues []linearIssue
+	err    error
+}
+
+func searchIssuesCmd(ctx context.Context, query string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := newLinearAPI()
+		if err != nil {
+			return searchResultMsg{query: query, err: err}
+		}
+
+		issues, err := client.searchIssues(ctx, query, searchLimit)
+		if errors.Is(err, context.Canceled) {
+			return searchResultMsg{query: query, err: err}
+		}
+		if err != nil {
+			logError(fmt.Sprintf("Failed to search issues for %q: %v", query, err))
+		} else {
+			storeLinearIssues(issues)
+		}
+
+		return searchResultMsg{query: query, issues: issues, err: err}
+	}
+}
+
+func (m *model) cancelSearch() {
+	if m.searchCancel != nil {
+		m.searchCancel()
+		m.searchCancel = nil
+	}
+}
+
+func (m model) openSearch() (model, tea.Cmd) {
+	m.screen = screenSearchIssues
+	m.searchInput.SetValue("")
+	m.searchInput.Focus()
+	m.searchQuery = ""
+	m.searchResults = nil
+	m.searchIndex = 0
+	m.searchLoading = false
+	m.searchError = ""
+
+	return m, textinput.Blink
+}
+
+func (m model) selectIssue(issue linearIssue) (model, tea.Cmd) {
+	m.screen = screenMain
+	m.searchInput.Blur()
+	m.cancelSearch()
+	m.cancelMyIssues()
+
+	title := truncateTitle(issue.Title, titleWidth)
+
+	if m.timerActive {
+		if issue.Identifier == m.input.Value() {
+			m.message = fmt.Sprintf("Already tracking %s.", issue.Identifier)
+			return m, nil
+		}
+
+		m.switchPolicy = loadSwitchPolicy()
+
+		return m.switchIssue(issue.Identifier)
+	}
+
+	m.input.SetValue(issue.Identifier)
+	m.lastInputValue = issue.Identifier
+	m.issueTitle = title
+
+	return m.beginTimer(issue.Identifier)
+}
+
+func (m model) updateSearch(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch message := msg.(type) {
+	case timerMsg:
+		if m.timerActive && !m.timerPaused {
+			return m.tick()
+		}
+
+		return m, nil
+
+	case searchDebounceMsg:
+		if message.query != strings.TrimSpace(m.searchInput.Value()) || message.query == "" {
+			return m, nil
+		}
+
+		m.cancelSearch()
+		ctx, cancel := context.WithTimeout(context.Background(), titleLookupTimeout)
+		m.searchCancel = cancel
+		m.searchLoading = true
+
+		return m, searchIssuesCmd(ctx, message.query)
+
+	case searchResultMsg:
+		if message.query != strings.TrimSpace(m.searchInput.Value()) || errors.Is(message.err, context.Canceled) {
+			return m, nil
+		}
+
+		m.cancelSearch()
+		m.searchLoading = false
+		m.searchIndex = 0
+		m.searchResults = message.issues
+		m.searchError = ""
+		if message.err != nil {
+			m.searchError = fmt.Sprintf("Search failed: %v", message.err)
+		}
+
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(message, searchKeys.Back):
+			m.screen = screenMain
+			m.searchInput.Blur()
+			m.cancelSearch()
+			m.message = "Search cancelled."
+
+			return m, nil
+
+		case key.Matches(message, searchKeys.Up):
+			if m.searchIndex > 0 {
+				m.searchIndex--
+			}
+
+			return m, nil
+
+		case key.Matches(message, searchKeys.Down):
+			if m.searchIndex < len(m.searchResults)-1 {
+				m.searchIndex++
+			}
+
+			return m, nil
+
+		case key.Matches(message, searchKeys.Select):
+			if len(m.searchResults) == 0 {
+				return m, nil
+			}
+
+			return m.selectIssue(m.searchResults[m.searchIndex])
+		}
+	}
+
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+
+	query := strings.TrimSpace(m.searchInput.Value())
+	if query != m.searchQuery {
+		m.searchQuery = query
+		m.cancelSearch()
+		if query == "" {
+			m.searchResults = nil
+			m.searchLoading = false
+
+			return m, cmd
+		}
+
+		debounce := tea.Tick(searchDebounce, func(time.Time) tea.Msg {
+			return searchDebounceMsg{query: query}
+		})
+		cmd = tea.Batch(cmd, debounce)
+	}
+
+	return m, cmd
+}
+
+func (m model) searchView() string {
+	titleLine := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		logoStyle.Render("⏱ unitrack"),
+		headerBar.Render("Search Issues"),
+	)
+
+	inputLine := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		inputLabel.Render("Search: "),
+		m.searchInput.View(),
+	)
+
+	var rows []string
+	switch {
+	case m.searchError != "":
+		rows = append(rows, listItemStyle.Render(m.searchError))
+	case m.searchLoading && len(m.searchResults) == 0:
+		rows = append(rows, listItemStyle.Render("Searching..."))
+	case m.searchQuery == "":
+		rows = append(rows, listItemStyle.Render("Type to search Linear issues by title or ID."))
+	case len(m.searchResults) == 0:
+		rows = append(rows, listItemStyle.Render("No matching issues."))
+	}
+
+	for i, issue := range m.searchResults {
+		cursor := "  "
+		style := listItemStyle
+		if i == m.searchIndex {
+			cursor = "> "
+			style = listSelectedStyle
+		}
+
+		title := truncateTitle(issue.Title, listTitleWidth)
+
+		line := fmt.Sprintf("%s%-10s %-14s %s", cursor, issue.Identifier, issue.State.Name, title)
+		rows = append(rows, style.Render(strings.TrimRight(line, " ")))
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		titleLine,
+		inputLine,
+		lipgloss.JoinVertical(lipgloss.Top, rows...),
+		helpStyle.Render(m.help.View(searchKeys)),
+	)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	switchPark   = "park"
+	switchSubmit = "submit"
+)
+
+func loadSwitchPolicy() string {
+	cfg, _ := currentConfig()
+
+	switch cfg.SwitchPolicy {
+	case "", switchPark:
+		return switchPark
+	case switchSubmit:
+		return switchSubmit
+	default:
+		logError(fmt.Sprintf("Invalid switch_policy %q, parking timers instead", cfg.SwitchPolicy))
+		return switchPark
+	}
+}
+
+func (m model) switchIssue(issueID string) (model, tea.Cmd) {
+	if m.daemon {
+		m.addHistory(issueID)
+
+		return m, daemonCmd(daemonRequest{Command: "start", IssueID: issueID})
+	}
+
+	previous := m.input.Value()
+	wasRunning := m.timerActive && !m.timerPaused
+
+	var cmds []tea.Cmd
+	action := "parked"
+	if m.switchPolicy == switchSubmit && loadRoundingPolicy().checkPostable(previous, m.timerValue) == nil {
+		var cmd tea.Cmd
+		m, cmd = m.submitActive(false)
+		cmds = append(cmds, cmd)
+		action = "submitted"
+	} else {
+		m.parkActive()
+	}
+
+	if saved := loadSavedTimer(issueID); saved != nil {
+		*saved = saved.settle(false)
+		m.removeParked(issueID)
+		m.restore(*saved)
+		saveTimer(m.snapshot())
+		m.message = fmt.Sprintf(
+			"Switched to %s at %s, %s %s.",
+			issueID,
+			fmtDuration(saved.Duration),
+			action,
+			previous,
+		)
+	} else {
+		m.startTimer(issueID)
+		m.message = fmt.Sprintf("Switched to %s, %s %s.", issueID, action, previous)
+	}
+
+	if !wasRunning {
+		cmds = append(cmds, tickTimer(), m.spinner.Tick)
+	}
+	cmds = append(cmds, fetchIssueTitleCmd(issueID, m.titles), startWorkflowCmd(issueID))
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m model) updateSwitchIssue(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch message := msg.(type) {
+	case timerMsg:
+		if m.timerActive && !m.timerPaused {
+			return m.tick()
+		}
+
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(message, m.keys.Start):
+			fullId, err := parseIssueID(m.switchInput.Value(), loadPrefix())
+			if err != nil {
+				m.message = issueIDErrorMessage(err)
+				return m, nil
+			}
+
+			m.screen = screenMain
+			m.switchInput.Blur()
+			if fullId == m.input.Value() {
+				m.message = fmt.Sprintf("Already tracking %s.", fullId)
+				return m, nil
+			}
+
+			return m.switchIssue(fullId)
+
+		case key.Matches(message, m.keys.Back):
+			m.screen = screenMain
+			m.switchInput.Blur()
+			m.message = "Switch cancelled."
+
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.switchInput, cmd = m.switchInput.Update(msg)
+
+	return m, cmd
+}
+
+func (m model) switchIssueView() string {
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			logoStyle.Render("⏱ unitrack"),
+			headerBar.Render("Switch Issue"),
+		),
+		inputLabel.Render(fmt.Sprintf("Current: %s (%s)", m.input.Value(), fmtDuration(m.timerValue))),
+		lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			inputLabel.Render("Switch to: "),
+			m.switchInput.View(),
+		),
+		msgStyle.Render(fmt.Sprintf(
+			"Press %s to %s the current timer and start the new one, %s to go back.",
+			m.keys.Start.Help().Key,
+			m.switchPolicy,
+			m.keys.Back.Help().Key,
+		)),
+	)
+}
@@ -0,0 +1,519 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type timerListKeyMap struct {
+	Up      key.Binding
+	Down    key.Binding
+	Switch  key.Binding
+	Submit  key.Binding
+	Discard key.Binding
+	New     key.Binding
+	Back    key.Binding
+}
+
+func (k timerListKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Switch, k.Submit, k.Discard, k.New, k.Back}
+}
+
+func (k timerListKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Switch},
+		{k.Submit, k.Discard, k.New, k.Back},
+	}
+}
+
+var listKeys = defaultTimerListKeyMap()
+
+func defaultTimerListKeyMap() timerListKeyMap {
+	return timerListKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		Switch: key.NewBinding(
+			key.WithKeys("enter", "r"),
+			key.WithHelp("enter", "switch/resume"),
+		),
+		Submit: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "submit"),
+		),
+		Discard: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "discard"),
+		),
+		New: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "park & new"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back"),
+		),
+	}
+}
+
+type timerListItem struct {
+	timer  savedTimer
+	active bool
+}
+
+func loadSavedTimers() []savedTimer {
+	files, err := filepath.Glob(os.Getenv("HOME") + "/.config/unitrack/saved_timer_*.json")
+	if err != nil {
+		return nil
+	}
+
+	var out []savedTimer
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+
+		var saved savedTimer
+		if json.Unmarshal(b, &saved) != nil || saved.IssueID == "" {
+			continue
+		}
+
+		if t := loadSavedTimer(saved.IssueID); t != nil {
+			out = append(out, *t)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].SavedAt.After(out[j].SavedAt)
+	})
+
+	return out
+}
+
+func (m model) snapshot() savedTimer {
+	return savedTimer{
+		IssueID:      m.input.Value(),
+		Title:        m.issueTitle,
+		Duration:     m.timerValue,
+		StartTime:    m.timerStart,
+		TotalPaused:  m.totalPaused,
+		LimitedTimer: m.limitedTimer,
+		TimerLimit:   m.timerLimit,
+		SessionStart: m.sessionStart,
+		Adjustment:   m.timerAdjust,
+		Paused:       m.timerPaused,
+		PausedAt:     m.pauseTime,
+		Snapshot:     true,
+	}
+}
+
+func (m model) pauseOnQuit() {
+	if !m.timerActive {
+		return
+	}
+
+	t := m.snapshot()
+	if !t.Paused {
+		t.Paused = true
+		t.PausedAt = time.Now()
+	}
+	saveTimer(t)
+}
+
+func (m *model) resetTimer() {
+	m.timerActive = false
+	m.timerPaused = false
+	m.limitedTimer = false
+	m.timerValue = 0
+	m.input.SetValue("")
+	m.issueTitle = ""
+	m.input.Focus()
+}
+
+func (m *model) restore(t savedTimer) {
+	now := time.Now()
+
+	m.input.SetValue(t.IssueID)
+	m.input.Blur()
+	m.historyNav = false
+	m.issueTitle = t.Title
+	m.timerActive = true
+	m.timerPaused = false
+	m.limitedTimer = t.LimitedTimer
+	m.timerLimit = t.TimerLimit
+	m.timerStart = now.Add(-t.Duration - t.TotalPaused)
+	m.totalPaused = t.TotalPaused
+	m.timerValue = t.Duration
+	m.sessionStart = t.SessionStart
+	if m.sessionStart.IsZero() {
+		m.sessionStart = t.StartTime
+	}
+	m.timerAdjust = t.Adjustment
+	m.lastSaveTime = now
+}
+
+func (m *model) parkActive() {
+	if !m.timerActive {
+		return
+	}
+
+	t := m.snapshot()
+	if !t.Paused {
+		t.Paused = true
+		t.PausedAt = time.Now()
+	}
+	t.Parked = true
+
+	saveTimer(t)
+	m.removeParked(t.IssueID)
+	m.parked = append(m.parked, t)
+	m.resetTimer()
+}
+
+func (m *model) removeParked(issueID string) {
+	var out []savedTimer
+	for _, t := range m.parked {
+		if t.IssueID != issueID {
+			out = append(out, t)
+		}
+	}
+	m.parked = out
+}
+
+func (m model) submitActive(auto bool) (model, tea.Cmd) {
+	policy := loadRoundingPolicy()
+	rounded := policy.format(m.timerValue)
+	issueId := m.input.Value()
+
+	if err := policy.checkPostable(issueId, m.timerValue); err != nil {
+		if !auto {
+			m.message = fmt.Sprintf("Not submitted: %v.", err)
+			return m, nil
+		}
+
+		logError(fmt.Sprintf("AUTO-SUBMIT SKIPPED: %v", err))
+		deleteSavedTimer(issueId)
+		m.resetTimer()
+		m.message = fmt.Sprintf("Time limit reached, not submitted: %v.", err)
+
+		return m, textinput.Blink
+	}
+
+	record := newSessionRecord(m.snapshot(), rounded, auto)
+
+	if auto {
+		m.message = fmt.Sprintf(
+			"Time limit reached! Posting %s (%s) to Linear for issue %s...",
+			rounded,
+			policy,
+			issueId,
+		)
+		logError(fmt.Sprintf("AUTO-SUBMIT ISSUE: %s TIME: %s ROUNDED: %s", issueId, fmtDuration(m.timerValue), rounded))
+	} else {
+		m.message = fmt.Sprintf("Posting %s (%s) to Linear for issue %s...", rounded, policy, issueId)
+		logError(fmt.Sprintf("SUBMIT ISSUE: %s TIME: %s ROUNDED: %s", issueId, fmtDuration(m.timerValue), rounded))
+	}
+
+	deleteSavedTimer(issueId)
+	submitCmd := submitSession(record)
+
+	if auto {
+		go showTimerNotification(issueId, rounded)
+	}
+
+	m.resetTimer()
+	m.history = loadHistory()
+
+	return m, tea.Batch(textinput.Blink, submitCmd)
+}
+
+func (m model) submitParked(t savedTimer) (model, tea.Cmd) {
+	policy := loadRoundingPolicy()
+	rounded := policy.format(t.Duration)
+
+	if err := policy.checkPostable(t.IssueID, t.Duration); err != nil {
+		m.message = fmt.Sprintf("Not submitted: %v.", err)
+		return m, nil
+	}
+
+	m.message = fmt.Sprintf("Posting %s (%s) to Linear for issue %s...", rounded, policy, t.IssueID)
+	logError(fmt.Sprintf("SUBMIT ISSUE: %s TIME: %s ROUNDED: %s", t.IssueID, fmtDuration(t.Duration), rounded))
+
+	deleteSavedTimer(t.IssueID)
+	m.removeParked(t.IssueID)
+
+	return m, submitSession(newSessionRecord(t, rounded, false))
+}
+
+func (m model) timerListItems() []timerListItem {
+	var items []timerListItem
+	if m.timerActive {
+		items = append(items, timerListItem{timer: m.snapshot(), active: true})
+	}
+	for _, t := range m.parked {
+		items = append(items, timerListItem{timer: t})
+	}
+
+	return items
+}
+
+func (m model) updateTimerList(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		if _, isTick := msg.(timerMsg); isTick && m.timerActive && !m.timerPaused {
+			return m.tick()
+		}
+
+		return m, nil
+	}
+
+	items := m.timerListItems()
+	if m.listIndex >= len(items) {
+		m.listIndex = len(items) - 1
+	}
+	if m.listIndex < 0 {
+		m.listIndex = 0
+	}
+
+	confirmDiscard := m.confirmDiscard
+	m.confirmDiscard = ""
+
+	switch {
+	case key.Matches(keyMsg, listKeys.Up):
+		if m.listIndex > 0 {
+			m.listIndex--
+		}
+
+	case key.Matches(keyMsg, listKeys.Down):
+		if m.listIndex < len(items)-1 {
+			m.listIndex++
+		}
+
+	case key.Matches(keyMsg, listKeys.Back):
+		m.screen = screenMain
+
+	case key.Matches(keyMsg, listKeys.New):
+		if m.timerActive && m.daemon {
+			m.screen = screenMain
+
+			return m, daemonCmd(daemonRequest{Command: "park"})
+		}
+		if m.timerActive {
+			issueId := m.input.Value()
+			m.parkActive()
+			m.message = fmt.Sprintf("Parked %s. Enter an issue ID to start a new timer.", issueId)
+		}
+		m.screen = screenMain
+
+		return m, textinput.Blink
+
+	case len(items) == 0:
+		return m, nil
+
+	case key.Matches(keyMsg, listKeys.Switch):
+		item := items[m.listIndex]
+		m.screen = screenMain
+		if item.active {
+			return m, nil
+		}
+		if m.daemon {
+			return m, daemonCmd(daemonRequest{Command: "start", IssueID: item.timer.IssueID})
+		}
+
+		wasRunning := m.timerActive && !m.timerPaused
+		var previous string
+		if m.timerActive {
+			previous = m.input.Value()
+		}
+		m.parkActive()
+		m.removeParked(item.timer.IssueID)
+		m.restore(item.timer)
+		saveTimer(m.snapshot())
+
+		if previous != "" {
+			m.message = fmt.Sprintf("Switched to %s, parked %s.", item.timer.IssueID, previous)
+		} else {
+			m.message = fmt.Sprintf("Resumed %s at %s.", item.timer.IssueID, fmtDuration(item.timer.Duration))
+		}
+
+		if wasRunning {
+			return m, nil
+		}
+
+		return m, tea.Batch(tickTimer(), m.spinner.Tick)
+
+	case key.Matches(keyMsg, listKeys.Submit):
+		item := items[m.listIndex]
+		if m.daemon {
+			return m, daemonCmd(daemonRequest{Command: "submit", IssueID: item.timer.IssueID})
+		}
+		if item.active {
+			return m.submitActive(false)
+		}
+
+		return m.submitParked(item.timer)
+
+	case key.Matches(keyMsg, listKeys.Discard):
+		item := items[m.listIndex]
+		if confirmDiscard != item.timer.IssueID {
+			m.confirmDiscard = item.timer.IssueID
+			m.message = fmt.Sprintf("Press '%s' again to discard the timer for %s.", listKeys.Discard.Help().Key, item.timer.IssueID)
+
+			return m, nil
+		}
+
+		if m.daemon {
+			return m, daemonCmd(daemonRequest{Command: "cancel", IssueID: item.timer.IssueID})
+		}
+
+		deleteSavedTimer(item.timer.IssueID)
+		if item.active {
+			m.resetTimer()
+		} else {
+			m.removeParked(item.timer.IssueID)
+		}
+		m.message = fmt.Sprintf("Discarded timer for %s.", item.timer.IssueID)
+	}
+
+	return m, nil
+}
+
+func (m model) timerListView() string {
+	titleLine := lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		logoStyle.Render("⏱ unitrack"),
+		headerBar.Render("Timers"),
+	)
+
+	var rows []string
+	items := m.timerListItems()
+	if len(items) == 0 {
+		rows = append(rows, listItemStyle.Render(fmt.Sprintf("No running or parked timers. Press '%s' to start one.", listKeys.New.Help().Key)))
+	}
+
+	for i, item := range items {
+		state := "parked"
+		value := item.timer.Duration
+		if item.active {
+			state = "running"
+			if m.timerPaused {
+				state = "paused"
+			}
+		}
+		if item.timer.LimitedTimer {
+			state += fmt.Sprintf(" (limit %s)", fmtDuration(item.timer.TimerLimit))
+		}
+
+		cursor := "  "
+		style := listItemStyle
+		if i == m.listIndex {
+			cursor = "> "
+			style = listSelectedStyle
+		}
+
+		line := fmt.Sprintf("%s%-12s %s  %-20s", cursor, item.timer.IssueID, fmtDuration(value), state)
+		if item.timer.Title != "" {
+			line += " " + item.timer.Title
+		}
+		rows = append(rows, style.Render(strings.TrimRight(line, " ")))
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		titleLine,
+		lipgloss.JoinVertical(lipgloss.Top, rows...),
+		msgStyle.Render(m.message),
+		helpStyle.Render(m.help.View(listKeys)),
+	)
+}
+
+func (m model) parkedView() string {
+	if len(m.parked) == 0 {
+		return ""
+	}
+
+	ids := make([]string, 0, len(m.parked))
+	for _, t := range m.parked {
+		ids = append(ids, t.IssueID)
+	}
+
+	return noticeStyle.Render(fmt.Sprintf(
+		"Parked: %s (press '%s' for the timer list)",
+		strings.Join(ids, ", "),
+		m.keys.Timers.Help().Key,
+	))
+}
+
+func (m *model) addHistory(issueID string) {
+	for _, h := range m.history {
+		if h == issueID {
+			return
+		}
+	}
+
+	m.history = append(m.history, issueID)
+	saveHistory(m.history)
+}
+
+func (m model) beginTimer(issueID string) (model, tea.Cmd) {
+	if m.daemon {
+		m.addHistory(issueID)
+		m.historyNav = false
+
+		return m, daemonCmd(daemonRequest{Command: "start", IssueID: issueID, Title: m.issueTitle})
+	}
+
+	if saved := loadSavedTimer(issueID); saved != nil {
+		*saved = saved.settle(false)
+		m.savedTimerIssue = issueID
+		m.savedTimerValue = saved.Duration
+		m.savedTimerLimited = saved.LimitedTimer
+		m.savedTimerLimit = saved.TimerLimit
+		m.savedTimerPaused = saved.TotalPaused
+		m.savedTimerAdjust = saved.Adjustment
+		m.savedTimerSession = saved.SessionStart
+		if m.savedTimerSession.IsZero() {
+			m.savedTimerSession = saved.StartTime
+		}
+		m.screen = screenRecoverTimer
+
+		return m, nil
+	}
+
+	m.startTimer(issueID)
+	m.message = "Timer started."
+
+	return m, tea.Batch(tickTimer(), m.spinner.Tick, startWorkflowCmd(issueID))
+}
+
+func (m *model) startTimer(issueID string) {
+	m.addHistory(issueID)
+
+	m.input.SetValue(issueID)
+	m.historyNav = false
+	m.timerActive = true
+	m.timerPaused = false
+	m.timerStart = time.Now()
+	m.sessionStart = m.timerStart
+	m.timerAdjust = 0
+	m.input.Blur()
+	m.timerValue = 0
+	m.totalPaused = 0
+	m.lastSaveTime = time.Now()
+}
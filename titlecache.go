@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTitleCacheTTL = 24 * time.Hour
+	titleWidth           = 70
+	listTitleWidth       = 60
+)
+
+type cachedIssue struct {
+	Title     string    `json:"title"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+var titleCacheMu sync.Mutex
+
+func titleCachePath() string {
+	return os.Getenv("HOME") + "/.config/unitrack/title_cache.json"
+}
+
+func titleCacheTTL() time.Duration {
+	cfg, _ := currentConfig()
+	if cfg.TitleCacheHours > 0 {
+		return time.Duration(cfg.TitleCacheHours) * time.Hour
+	}
+
+	return defaultTitleCacheTTL
+}
+
+func readTitleCache() map[string]cachedIssue {
+	entries := make(map[string]cachedIssue)
+
+	b, err := os.ReadFile(titleCachePath())
+	if err != nil {
+		return entries
+	}
+
+	if err = json.Unmarshal(b, &entries); err != nil {
+		logError(fmt.Sprintf("Failed to unmarshal title cache: %v", err))
+		return make(map[string]cachedIssue)
+	}
+
+	return entries
+}
+
+func writeTitleCache(entries map[string]cachedIssue) {
+	_ = os.MkdirAll(os.Getenv("HOME")+"/.config/unitrack", 0700)
+
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		logError(fmt.Sprintf("Failed to marshal title cache: %v", err))
+		return
+	}
+
+	tmp := titleCachePath() + ".tmp"
+	if err = os.WriteFile(tmp, b, 0600); err != nil {
+		logError(fmt.Sprintf("Failed to write title cache: %v", err))
+		return
+	}
+
+	if err = os.Rename(tmp, titleCachePath()); err != nil {
+		logError(fmt.Sprintf("Failed to replace title cache: %v", err))
+	}
+}
+
+func storeIssueTitles(titles map[string]string) {
+	if len(titles) == 0 {
+		return
+	}
+
+	titleCacheMu.Lock()
+	defer titleCacheMu.Unlock()
+
+	entries := readTitleCache()
+	now := time.Now()
+	for issueID, title := range titles {
+		entries[issueID] = cachedIssue{Title: title, FetchedAt: now}
+	}
+	writeTitleCache(entries)
+}
+
+func storeIssueTitle(issueID, title string) {
+	storeIssueTitles(map[string]string{issueID: title})
+}
+
+func storeLinearIssues(issues []linearIssue) {
+	titles := make(map[string]string, len(issues))
+	for _, issue := range issues {
+		titles[issue.Identifier] = issue.Title
+	}
+	storeIssueTitles(titles)
+}
+
+func lookupCachedTitle(issueID string) (string, bool, bool) {
+	titleCacheMu.Lock()
+	entry, ok := readTitleCache()[issueID]
+	titleCacheMu.Unlock()
+
+	if !ok {
+		return "", false, false
+	}
+
+	return entry.Title, time.Since(entry.FetchedAt) < titleCacheTTL(), true
+}
+
+func resolveIssueTitle(ctx context.Context, issueID string) (string, error) {
+	cached, fresh, ok := lookupCachedTitle(issueID)
+	if ok && fresh {
+		return cached, nil
+	}
+
+	title, err := fetchLinearTitle(ctx, issueID)
+	if err != nil {
+		if ok {
+			logError(fmt.Sprintf("Using cached title for %s: %v", issueID, err))
+			return cached, nil
+		}
+
+		return "", err
+	}
+
+	storeIssueTitle(issueID, title)
+
+	return title, nil
+}
+
+func fetchLinearTitle(ctx context.Context, issueID string) (string, error) {
+	client, err := newLinearAPI()
+	if err != nil {
+		return "", err
+	}
+
+	return client.issueTitle(ctx, issueID)
+}
+
+func truncateTitle(title string, width int) string {
+	runes := []rune(title)
+	if len(runes) > width {
+		return string(runes[:width-3]) + "..."
+	}
+
+	return title
+}
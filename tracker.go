@@ -0,0 +1,465 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	errNoActiveTimer = errors.New("no timer is running")
+	errTimersInUse   = errors.New("the timers are in use by unitrack running without the daemon; change them there or quit it first")
+)
+
+type timerStatus struct {
+	IssueID   string        `json:"issue_id"`
+	Title     string        `json:"title,omitempty"`
+	Elapsed   time.Duration `json:"elapsed"`
+	Paused    bool          `json:"paused"`
+	Limited   bool          `json:"limited"`
+	Limit     time.Duration `json:"limit,omitempty"`
+	StartedAt time.Time     `json:"started_at"`
+}
+
+type trackerStatus struct {
+	Active *timerStatus  `json:"active,omitempty"`
+	Parked []timerStatus `json:"parked,omitempty"`
+}
+
+type trackerResult struct {
+	message   string
+	submitted []sessionRecord
+}
+
+type tracker struct {
+	mu     sync.Mutex
+	active *savedTimer
+	parked []savedTimer
+}
+
+func (t savedTimer) elapsed(now time.Time) time.Duration {
+	if t.StartTime.IsZero() {
+		return t.Duration
+	}
+
+	end := now
+	if t.Paused {
+		if t.PausedAt.IsZero() {
+			return t.Duration
+		}
+		end = t.PausedAt
+	}
+
+	elapsed := end.Sub(t.StartTime) - t.TotalPaused
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	if t.LimitedTimer && elapsed > t.TimerLimit {
+		elapsed = t.TimerLimit
+	}
+
+	return elapsed
+}
+
+func timersLockPath() string {
+	return os.Getenv("HOME") + "/.config/unitrack/timers.lock"
+}
+
+func lockTimers() (func(), bool) {
+	unlock, ok, err := lockFile(timersLockPath(), false)
+	if err != nil {
+		logError(fmt.Sprintf("Failed to lock saved timers: %v", err))
+		return func() {}, true
+	}
+
+	return unlock, ok
+}
+
+func timersInUse() bool {
+	unlock, ok := lockTimers()
+	if ok {
+		unlock()
+	}
+
+	return !ok
+}
+
+func (t savedTimer) settle(live bool) savedTimer {
+	if t.Snapshot && !live {
+		if !t.Paused {
+			t.Paused = true
+			t.PausedAt = t.SavedAt
+		}
+		t.Snapshot = false
+	}
+	t.Duration = t.elapsed(time.Now())
+
+	return t
+}
+
+func (t savedTimer) status(now time.Time) timerStatus {
+	started := t.SessionStart
+	if started.IsZero() {
+		started = t.StartTime
+	}
+
+	return timerStatus{
+		IssueID:   t.IssueID,
+		Title:     t.Title,
+		Elapsed:   t.elapsed(now),
+		Paused:    t.Paused,
+		Limited:   t.LimitedTimer,
+		Limit:     t.TimerLimit,
+		StartedAt: started,
+	}
+}
+
+func loadTracker(live bool) *tracker {
+	tr := &tracker{}
+	for _, t := range loadSavedTimers() {
+		t = t.settle(live)
+		if tr.active == nil && !t.Parked {
+			active := t
+			tr.active = &active
+			continue
+		}
+
+		if !t.Paused {
+			t.Paused = true
+			t.PausedAt = t.SavedAt
+		}
+		t.Parked = true
+		tr.parked = append(tr.parked, t)
+	}
+
+	return tr
+}
+
+func (tr *tracker) status() trackerStatus {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	return tr.statusLocked()
+}
+
+func (tr *tracker) statusLocked() trackerStatus {
+	now := time.Now()
+
+	var status trackerStatus
+	if tr.active != nil {
+		active := tr.active.status(now)
+		status.Active = &active
+	}
+	for _, t := range tr.parked {
+		status.Parked = append(status.Parked, t.status(now))
+	}
+
+	return status
+}
+
+func (tr *tracker) persist(t savedTimer) {
+	t.Duration = t.elapsed(time.Now())
+	saveTimer(t)
+}
+
+func (tr *tracker) takeParked(issueID string) (savedTimer, bool) {
+	for i, t := range tr.parked {
+		if t.IssueID == issueID {
+			tr.parked = append(tr.parked[:i], tr.parked[i+1:]...)
+			return t, true
+		}
+	}
+
+	return savedTimer{}, false
+}
+
+func (tr *tracker) parkLocked() {
+	if tr.active == nil {
+		return
+	}
+
+	t := *tr.active
+	if !t.Paused {
+		t.Paused = true
+		t.PausedAt = time.Now()
+	}
+	t.Parked = true
+	tr.persist(t)
+	tr.parked = append(tr.parked, t)
+	tr.active = nil
+}
+
+func (tr *tracker) submitLocked(t savedTimer, auto bool) sessionRecord {
+	t.Duration = t.elapsed(time.Now())
+	rounded := loadRoundingPolicy().format(t.Duration)
+
+	if auto {
+		logError(fmt.Sprintf("AUTO-SUBMIT ISSUE: %s TIME: %s ROUNDED: %s", t.IssueID, fmtDuration(t.Duration), rounded))
+	} else {
+		logError(fmt.Sprintf("SUBMIT ISSUE: %s TIME: %s ROUNDED: %s", t.IssueID, fmtDuration(t.Duration), rounded))
+	}
+
+	deleteSavedTimer(t.IssueID)
+
+	return newSessionRecord(t, rounded, auto)
+}
+
+func (tr *tracker) start(issueID, title string, limit time.Duration) (trackerResult, error) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	var result trackerResult
+	if tr.active != nil && tr.active.IssueID == issueID {
+		return result, fmt.Errorf("already tracking %s", issueID)
+	}
+
+	if tr.active != nil {
+		previous := tr.active.IssueID
+		postable := loadRoundingPolicy().checkPostable(previous, tr.active.elapsed(time.Now())) == nil
+		if loadSwitchPolicy() == switchSubmit && postable {
+			result.submitted = append(result.submitted, tr.submitLocked(*tr.active, false))
+			tr.active = nil
+			result.message = fmt.Sprintf("Submitted %s. ", previous)
+		} else {
+			tr.parkLocked()
+			result.message = fmt.Sprintf("Parked %s. ", previous)
+		}
+	}
+
+	now := time.Now()
+	t, ok := tr.takeParked(issueID)
+	if !ok {
+		if saved := loadSavedTimer(issueID); saved != nil {
+			t, ok = saved.settle(false), true
+			if !t.Paused {
+				t.StartTime = now.Add(-t.Duration - t.TotalPaused)
+			}
+		}
+	}
+
+	if ok {
+		t.Parked = false
+		if t.Paused {
+			t.TotalPaused += now.Sub(t.PausedAt)
+			t.Paused = false
+			t.PausedAt = time.Time{}
+		}
+		result.message += fmt.Sprintf("Resumed %s at %s.", issueID, fmtDuration(t.elapsed(now)))
+	} else {
+		t = savedTimer{
+			IssueID:      issueID,
+			StartTime:    now,
+			SessionStart: now,
+		}
+		result.message += fmt.Sprintf("Started timer for %s.", issueID)
+	}
+
+	if title != "" {
+		t.Title = title
+	}
+	if limit > 0 {
+		t.LimitedTimer = true
+		t.TimerLimit = limit
+	}
+
+	tr.active = &t
+	tr.persist(t)
+
+	return result, nil
+}
+
+func (tr *tracker) park() (trackerResult, error) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if tr.active == nil {
+		return trackerResult{}, errNoActiveTimer
+	}
+
+	issueID := tr.active.IssueID
+	tr.parkLocked()
+
+	return trackerResult{message: fmt.Sprintf("Parked %s.", issueID)}, nil
+}
+
+func (tr *tracker) pause() (trackerResult, error) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if tr.active == nil {
+		return trackerResult{}, errNoActiveTimer
+	}
+	if tr.active.Paused {
+		return trackerResult{}, fmt.Errorf("%s is already paused", tr.active.IssueID)
+	}
+
+	tr.active.Paused = true
+	tr.active.PausedAt = time.Now()
+	tr.persist(*tr.active)
+
+	return trackerResult{message: fmt.Sprintf("Paused %s.", tr.active.IssueID)}, nil
+}
+
+func (tr *tracker) resume() (trackerResult, error) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if tr.active == nil {
+		return trackerResult{}, errNoActiveTimer
+	}
+	if !tr.active.Paused {
+		return trackerResult{}, fmt.Errorf("%s is not paused", tr.active.IssueID)
+	}
+
+	tr.active.TotalPaused += time.Since(tr.active.PausedAt)
+	tr.active.Paused = false
+	tr.active.PausedAt = time.Time{}
+	tr.persist(*tr.active)
+
+	return trackerResult{message: fmt.Sprintf("Resumed %s.", tr.active.IssueID)}, nil
+}
+
+func (tr *tracker) adjust(delta time.Duration) (trackerResult, error) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if tr.active == nil {
+		return trackerResult{}, errNoActiveTimer
+	}
+
+	elapsed := tr.active.elapsed(time.Now())
+	if elapsed+delta < 0 {
+		return trackerResult{}, fmt.Errorf("cannot subtract %s from %s", delta.Abs(), fmtDuration(elapsed))
+	}
+	if tr.active.LimitedTimer && elapsed+delta > tr.active.TimerLimit {
+		return trackerResult{}, fmt.Errorf("cannot add %s: limit is %s", delta, fmtDuration(tr.active.TimerLimit))
+	}
+
+	tr.active.StartTime = tr.active.StartTime.Add(-delta)
+	tr.active.Adjustment += delta
+	tr.persist(*tr.active)
+
+	return trackerResult{message: fmt.Sprintf("Adjusted %s by %s.", tr.active.IssueID, delta)}, nil
+}
+
+func (tr *tracker) submit(issueID string, dryRun bool) (trackerResult, error) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	var t savedTimer
+	active := tr.active != nil && (issueID == "" || tr.active.IssueID == issueID)
+	switch {
+	case active:
+		t = *tr.active
+	case issueID == "":
+		return trackerResult{}, errNoActiveTimer
+	default:
+		var ok bool
+		for _, p := range tr.parked {
+			if p.IssueID == issueID {
+				t, ok = p, true
+			}
+		}
+		if !ok {
+			return trackerResult{}, fmt.Errorf("no timer for %s", issueID)
+		}
+	}
+
+	if err := loadRoundingPolicy().checkPostable(t.IssueID, t.elapsed(time.Now())); err != nil {
+		return trackerResult{}, err
+	}
+
+	if dryRun {
+		t.Duration = t.elapsed(time.Now())
+		policy := loadRoundingPolicy()
+		record := newSessionRecord(t, policy.format(t.Duration), false)
+
+		return trackerResult{
+			message:   fmt.Sprintf("Would post %s (%s) for %s.", record.Rounded, policy, t.IssueID),
+			submitted: []sessionRecord{record},
+		}, nil
+	}
+
+	record := tr.submitLocked(t, false)
+	if active {
+		tr.active = nil
+	} else {
+		tr.takeParked(t.IssueID)
+	}
+
+	return trackerResult{
+		message:   fmt.Sprintf("Submitting %s for %s.", record.Rounded, t.IssueID),
+		submitted: []sessionRecord{record},
+	}, nil
+}
+
+func (tr *tracker) cancel(issueID string) (trackerResult, error) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if tr.active != nil && (issueID == "" || tr.active.IssueID == issueID) {
+		issueID = tr.active.IssueID
+		tr.active = nil
+		deleteSavedTimer(issueID)
+
+		return trackerResult{message: fmt.Sprintf("Cancelled timer for %s.", issueID)}, nil
+	}
+
+	if issueID == "" {
+		return trackerResult{}, errNoActiveTimer
+	}
+
+	if _, ok := tr.takeParked(issueID); !ok {
+		return trackerResult{}, fmt.Errorf("no timer for %s", issueID)
+	}
+	deleteSavedTimer(issueID)
+
+	return trackerResult{message: fmt.Sprintf("Cancelled timer for %s.", issueID)}, nil
+}
+
+func (tr *tracker) setTitle(issueID, title string) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if tr.active != nil && tr.active.IssueID == issueID && tr.active.Title == "" {
+		tr.active.Title = title
+		tr.persist(*tr.active)
+	}
+}
+
+func (tr *tracker) checkLimit() []sessionRecord {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if tr.active == nil || !tr.active.LimitedTimer || tr.active.Paused {
+		return nil
+	}
+
+	if tr.active.elapsed(time.Now()) < tr.active.TimerLimit {
+		return nil
+	}
+
+	if err := loadRoundingPolicy().checkPostable(tr.active.IssueID, tr.active.elapsed(time.Now())); err != nil {
+		logError(fmt.Sprintf("AUTO-SUBMIT SKIPPED: %v", err))
+		deleteSavedTimer(tr.active.IssueID)
+		tr.active = nil
+
+		return nil
+	}
+
+	record := tr.submitLocked(*tr.active, true)
+	tr.active = nil
+
+	return []sessionRecord{record}
+}
+
+func (tr *tracker) save() {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if tr.active != nil {
+		tr.persist(*tr.active)
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTrackerStartParkSubmit(t *testing.T) {
+	testHome(t, `{"api_key": "test-key"}`)
+
+	tr := loadTracker(false)
+	if _, err := tr.start("UE-1", "Fix login", 0); err != nil {
+		t.Fatalf("start(UE-1) error = %v", err)
+	}
+	if _, err := tr.start("UE-1", "", 0); err == nil {
+		t.Error("start(UE-1) twice error = nil, want already tracking")
+	}
+	tr.active.StartTime = tr.active.StartTime.Add(-20 * time.Minute)
+
+	result, err := tr.start("UE-2", "", 0)
+	if err != nil {
+		t.Fatalf("start(UE-2) error = %v", err)
+	}
+	if result.message != "Parked UE-1. Started timer for UE-2." {
+		t.Errorf("start(UE-2) message = %q", result.message)
+	}
+
+	status := tr.status()
+	if status.Active == nil || status.Active.IssueID != "UE-2" {
+		t.Fatalf("active = %+v, want UE-2", status.Active)
+	}
+	if len(status.Parked) != 1 || status.Parked[0].IssueID != "UE-1" || !status.Parked[0].Paused {
+		t.Fatalf("parked = %+v, want paused UE-1", status.Parked)
+	}
+	if elapsed := status.Parked[0].Elapsed; elapsed < 20*time.Minute || elapsed > 21*time.Minute {
+		t.Errorf("parked elapsed = %s, want about 20m", elapsed)
+	}
+
+	if _, err = tr.park(); err != nil {
+		t.Fatalf("park() error = %v", err)
+	}
+	if _, err = tr.park(); !errors.Is(err, errNoActiveTimer) {
+		t.Errorf("park() without a timer error = %v, want errNoActiveTimer", err)
+	}
+
+	reloaded := loadTracker(false).status()
+	if reloaded.Active != nil || len(reloaded.Parked) != 2 {
+		t.Errorf("reloaded = %+v, want two parked timers", reloaded)
+	}
+
+	if _, err = tr.submit("UE-2", false); err == nil {
+		t.Error("submit(UE-2) error = nil, want nothing to post")
+	}
+
+	result, err = tr.submit("UE-1", false)
+	if err != nil {
+		t.Fatalf("submit(UE-1) error = %v", err)
+	}
+	if len(result.submitted) != 1 || result.submitted[0].Rounded != "0:30" || result.submitted[0].Title != "Fix login" {
+		t.Errorf("submitted = %+v, want 0:30 for UE-1", result.submitted)
+	}
+	if loadSavedTimer("UE-1") != nil {
+		t.Error("saved timer for UE-1 still exists after submit")
+	}
+	if _, err = tr.submit("UE-1", false); err == nil {
+		t.Error("submit(UE-1) twice error = nil, want no timer")
+	}
+
+	if _, err = tr.start("UE-2", "", 0); err != nil {
+		t.Fatalf("resume UE-2 error = %v", err)
+	}
+	if status = tr.status(); status.Active == nil || status.Active.IssueID != "UE-2" || status.Active.Paused {
+		t.Errorf("active = %+v, want running UE-2", status.Active)
+	}
+}
+
+func TestTrackerCheckLimit(t *testing.T) {
+	testHome(t, `{"api_key": "test-key"}`)
+
+	tr := loadTracker(false)
+	if _, err := tr.start("UE-1", "", 30*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if records := tr.checkLimit(); records != nil {
+		t.Fatalf("checkLimit() = %+v before the limit", records)
+	}
+
+	tr.active.StartTime = tr.active.StartTime.Add(-time.Hour)
+	records := tr.checkLimit()
+	if len(records) != 1 || !records[0].AutoSubmit || records[0].Rounded != "0:30" {
+		t.Errorf("checkLimit() = %+v, want one auto-submitted 0:30 session", records)
+	}
+	if tr.status().Active != nil {
+		t.Error("timer still active after reaching its limit")
+	}
+}
+
+func TestSavedTimerSettle(t *testing.T) {
+	now := time.Now()
+	start := now.Add(-16 * time.Hour)
+	savedAt := now.Add(-15 * time.Hour)
+
+	tests := []struct {
+		name       string
+		timer      savedTimer
+		live       bool
+		want       time.Duration
+		wantPaused bool
+	}{
+		{
+			name:  "headless timer keeps running",
+			timer: savedTimer{StartTime: start, SavedAt: savedAt},
+			want:  16 * time.Hour,
+		},
+		{
+			name:       "snapshot of a closed TUI stops at SavedAt",
+			timer:      savedTimer{StartTime: start, SavedAt: savedAt, Snapshot: true},
+			want:       time.Hour,
+			wantPaused: true,
+		},
+		{
+			name:  "snapshot of a live TUI keeps running",
+			timer: savedTimer{StartTime: start, SavedAt: savedAt, Snapshot: true},
+			live:  true,
+			want:  16 * time.Hour,
+		},
+		{
+			name:       "paused snapshot",
+			timer:      savedTimer{StartTime: start, SavedAt: savedAt, Snapshot: true, Paused: true, PausedAt: start.Add(30 * time.Minute)},
+			want:       30 * time.Minute,
+			wantPaused: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.timer.settle(tt.live)
+			if diff := got.Duration - tt.want; diff < 0 || diff > time.Second {
+				t.Errorf("settle().Duration = %s, want %s", got.Duration, tt.want)
+			}
+			if got.Paused != tt.wantPaused {
+				t.Errorf("settle().Paused = %v, want %v", got.Paused, tt.wantPaused)
+			}
+		})
+	}
+}
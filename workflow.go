@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type issueWorkflowMsg struct {
+	text string
+}
+
+func firstStartedState(states []workflowState) (workflowState, bool) {
+	var first workflowState
+	found := false
+	for _, s := range states {
+		if s.Type != "started" {
+			continue
+		}
+		if !found || s.Position < first.Position {
+			first = s
+			found = true
+		}
+	}
+
+	return first, found
+}
+
+func markIssueStarted(ctx context.Context, issueID string, move, assign bool) (string, error) {
+	client, err := newLinearAPI()
+	if err != nil {
+		return "", err
+	}
+
+	info, err := client.issueStartInfo(ctx, issueID)
+	if err != nil {
+		return "", err
+	}
+
+	input := make(map[string]any)
+	if move {
+		switch info.State.Type {
+		case "triage", "backlog", "unstarted":
+			started, ok := firstStartedState(info.StartedStates)
+			if !ok {
+				return "", fmt.Errorf("the team of %s has no started workflow state", issueID)
+			}
+			input["stateId"] = started.ID
+		}
+	}
+	if assign && info.AssigneeID == "" && info.ViewerID != "" {
+		input["assigneeId"] = info.ViewerID
+	}
+
+	if len(input) == 0 {
+		return "", nil
+	}
+
+	state, err := client.updateIssue(ctx, issueID, input)
+	if err != nil {
+		return "", err
+	}
+
+	_, moved := input["stateId"]
+	_, assigned := input["assigneeId"]
+	switch {
+	case moved && assigned:
+		return fmt.Sprintf("Moved %s to %s and assigned it to you.", issueID, state), nil
+	case moved:
+		return fmt.Sprintf("Moved %s to %s.", issueID, state), nil
+	default:
+		return fmt.Sprintf("Assigned %s to you.", issueID), nil
+	}
+}
+
+func startWorkflowCmd(issueID string) tea.Cmd {
+	cfg, _ := currentConfig()
+	if !cfg.MoveOnStart && !cfg.AssignOnStart {
+		return nil
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), titleLookupTimeout)
+		defer cancel()
+
+		text, err := markIssueStarted(ctx, issueID, cfg.MoveOnStart, cfg.AssignOnStart)
+		if err != nil {
+			logError(fmt.Sprintf("Failed to update %s on start: %v", issueID, err))
+			return issueWorkflowMsg{text: fmt.Sprintf("Could not update %s in Linear: %v", issueID, err)}
+		}
+		if text == "" {
+			return nil
+		}
+
+		return issueWorkflowMsg{text: text}
+	}
+}